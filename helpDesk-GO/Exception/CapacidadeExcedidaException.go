@@ -0,0 +1,17 @@
+package Exception
+
+import "fmt"
+
+type CapacidadeExcedidaException struct {
+	BalcaoID   int64
+	Capacidade int
+	Abertos    int64
+}
+
+func (e *CapacidadeExcedidaException) Error() string {
+	return fmt.Sprintf("A capacidade %d é menor que os %d atendimentos em aberto no balcão %d.", e.Capacidade, e.Abertos, e.BalcaoID)
+}
+
+func (e *CapacidadeExcedidaException) Tipo() Tipo { return TipoCapacidadeExcedida }
+
+func (e *CapacidadeExcedidaException) CodigoErro() string { return CodigoCapacidadeExcedida }
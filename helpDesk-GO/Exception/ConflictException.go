@@ -5,17 +5,27 @@ import "fmt"
 type ConflictException struct {
 	Message string
 	Uri     string
+	Codigo  string
 }
 
 func (e *ConflictException) Error() string {
 	return fmt.Sprintf("Conflict: %s", e.Message)
 }
 
+func (e *ConflictException) Tipo() Tipo { return TipoConflito }
+
+func (e *ConflictException) CodigoErro() string { return codigoOuPadrao(e.Codigo, CodigoConflito) }
+
 type ForbiddenException struct {
 	Message string
 	Uri     string
+	Codigo  string
 }
 
 func (e *ForbiddenException) Error() string {
 	return fmt.Sprintf("Forbidden: %s", e.Message)
 }
+
+func (e *ForbiddenException) Tipo() Tipo { return TipoProibido }
+
+func (e *ForbiddenException) CodigoErro() string { return codigoOuPadrao(e.Codigo, CodigoProibido) }
@@ -0,0 +1,53 @@
+package Exception
+
+// Tipo agrupa os erros de domínio pela forma como devem ser tratados por
+// quem chama o serviço; a camada HTTP traduz cada tipo em um status.
+type Tipo int
+
+const (
+	TipoNaoEncontrado Tipo = iota + 1
+	TipoConflito
+	TipoProibido
+	TipoValidacao
+	TipoCapacidadeExcedida
+	TipoPrecondicaoFalhou
+	TipoPrecondicaoObrigatoria
+)
+
+// Códigos estáveis devolvidos aos clientes da API. Podem ser usados em
+// comparações; a mensagem não.
+const (
+	CodigoNaoEncontrado          = "NAO_ENCONTRADO"
+	CodigoConflito               = "CONFLITO"
+	CodigoProibido               = "PROIBIDO"
+	CodigoValidacao              = "VALIDACAO"
+	CodigoCapacidadeExcedida     = "CAPACIDADE_EXCEDIDA"
+	CodigoTransicaoInvalida      = "TRANSICAO_INVALIDA"
+	CodigoSerialEmUso            = "SERIAL_EM_USO"
+	CodigoAtendenteOcupado       = "ATENDENTE_OCUPADO"
+	CodigoAtendenteDuplicado     = "ATENDENTE_DUPLICADO"
+	CodigoAtendenteInativo       = "ATENDENTE_INATIVO"
+	CodigoAtendenteComBalcao     = "ATENDENTE_COM_BALCAO"
+	CodigoClienteDuplicado       = "CLIENTE_DUPLICADO"
+	CodigoDispositivoDuplicado   = "DISPOSITIVO_DUPLICADO"
+	CodigoProdutoDuplicado       = "PRODUTO_DUPLICADO"
+	CodigoBalcaoDuplicado        = "BALCAO_DUPLICADO"
+	CodigoBalcaoComFila          = "BALCAO_COM_FILA"
+	CodigoBalcaoInativo          = "BALCAO_INATIVO"
+	CodigoErroInterno            = "ERRO_INTERNO"
+	CodigoVersaoDesatualizada    = "VERSAO_DESATUALIZADA"
+	CodigoPrecondicaoObrigatoria = "PRECONDICAO_OBRIGATORIA"
+)
+
+type ErroDominio interface {
+	error
+	Tipo() Tipo
+	CodigoErro() string
+}
+
+func codigoOuPadrao(codigo, padrao string) string {
+	if codigo != "" {
+		return codigo
+	}
+	return padrao
+}
@@ -0,0 +1,26 @@
+package Exception
+
+import "fmt"
+
+// NotFoundException identifica o recurso pelo ID ou, quando ele é buscado por
+// outra chave (como o serial de um dispositivo), pela Chave.
+type NotFoundException struct {
+	Recurso string
+	ID      int64
+	Chave   string
+}
+
+func (e *NotFoundException) Error() string {
+	recurso := e.Recurso
+	if recurso == "" {
+		recurso = "O recurso"
+	}
+	if e.Chave != "" {
+		return fmt.Sprintf("%s %s não foi encontrado", recurso, e.Chave)
+	}
+	return fmt.Sprintf("%s com ID %d não foi encontrado", recurso, e.ID)
+}
+
+func (e *NotFoundException) Tipo() Tipo { return TipoNaoEncontrado }
+
+func (e *NotFoundException) CodigoErro() string { return CodigoNaoEncontrado }
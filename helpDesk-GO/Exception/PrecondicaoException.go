@@ -0,0 +1,34 @@
+package Exception
+
+import "fmt"
+
+// VersaoDesatualizadaException indica que o registro foi alterado por outra
+// requisição depois que o cliente o leu.
+type VersaoDesatualizadaException struct {
+	Recurso  string
+	ID       int64
+	Esperada int64
+	Atual    int64
+}
+
+func (e *VersaoDesatualizadaException) Error() string {
+	if e.Atual == 0 {
+		return fmt.Sprintf("%s %d foi alterado por outra requisição. Busque-o novamente antes de editar.", e.Recurso, e.ID)
+	}
+	return fmt.Sprintf("%s %d está na versão %d, mas a requisição esperava a versão %d.", e.Recurso, e.ID, e.Atual, e.Esperada)
+}
+
+func (e *VersaoDesatualizadaException) Tipo() Tipo { return TipoPrecondicaoFalhou }
+
+func (e *VersaoDesatualizadaException) CodigoErro() string { return CodigoVersaoDesatualizada }
+
+// PrecondicaoObrigatoriaException indica uma edição enviada sem If-Match.
+type PrecondicaoObrigatoriaException struct {
+	Message string
+}
+
+func (e *PrecondicaoObrigatoriaException) Error() string { return e.Message }
+
+func (e *PrecondicaoObrigatoriaException) Tipo() Tipo { return TipoPrecondicaoObrigatoria }
+
+func (e *PrecondicaoObrigatoriaException) CodigoErro() string { return CodigoPrecondicaoObrigatoria }
@@ -0,0 +1,23 @@
+package Exception
+
+import "fmt"
+
+// TransicaoInvalidaException recusa uma mudança de status. Motivo, quando
+// preenchido, explica por que a transição não é permitida naquele caminho.
+type TransicaoInvalidaException struct {
+	De     string
+	Para   string
+	Motivo string
+}
+
+func (e *TransicaoInvalidaException) Error() string {
+	mensagem := fmt.Sprintf("Transição de status inválida: %s -> %s", e.De, e.Para)
+	if e.Motivo != "" {
+		mensagem += ". " + e.Motivo
+	}
+	return mensagem
+}
+
+func (e *TransicaoInvalidaException) Tipo() Tipo { return TipoConflito }
+
+func (e *TransicaoInvalidaException) CodigoErro() string { return CodigoTransicaoInvalida }
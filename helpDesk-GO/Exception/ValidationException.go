@@ -0,0 +1,35 @@
+package Exception
+
+import (
+	"sort"
+	"strings"
+)
+
+// ValidationException indica dados de entrada inválidos. Campos, quando
+// preenchido, associa cada campo com problema à sua mensagem.
+type ValidationException struct {
+	Message string
+	Campos  map[string]string
+}
+
+func (e *ValidationException) Error() string {
+	if len(e.Campos) == 0 {
+		return e.Message
+	}
+
+	nomes := make([]string, 0, len(e.Campos))
+	for nome := range e.Campos {
+		nomes = append(nomes, nome)
+	}
+	sort.Strings(nomes)
+
+	problemas := make([]string, 0, len(nomes))
+	for _, nome := range nomes {
+		problemas = append(problemas, nome+": "+e.Campos[nome])
+	}
+	return e.Message + " (" + strings.Join(problemas, "; ") + ")"
+}
+
+func (e *ValidationException) Tipo() Tipo { return TipoValidacao }
+
+func (e *ValidationException) CodigoErro() string { return CodigoValidacao }
@@ -0,0 +1,199 @@
+package config
+
+import (
+	"fmt"
+	"github.com/go-sql-driver/mysql"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const prefixoAmbiente = "HELPDESK_"
+
+type Config struct {
+	Storage          string
+	DSN              string
+	ListenAddr       string
+	LimiteFila       int
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+	ShutdownTimeout  time.Duration
+	LogLevel         slog.Level
+	GarantiaMeses    int
+	GarantiaArquivo  string
+	BalcaoReparoPago int64
+}
+
+type ErroConfig struct {
+	Chaves map[string]string
+}
+
+func (e *ErroConfig) Error() string {
+	chaves := make([]string, 0, len(e.Chaves))
+	for chave := range e.Chaves {
+		chaves = append(chaves, chave)
+	}
+	sort.Strings(chaves)
+
+	problemas := make([]string, 0, len(chaves))
+	for _, chave := range chaves {
+		problemas = append(problemas, fmt.Sprintf("%s: %s", chave, e.Chaves[chave]))
+	}
+	return "configuração inválida: " + strings.Join(problemas, "; ")
+}
+
+var padroes = map[string]string{
+	"storage":            "mysql",
+	"dsn":                "",
+	"listen_addr":        ":8080",
+	"limite_fila":        "5",
+	"read_timeout":       "10s",
+	"write_timeout":      "30s",
+	"shutdown_timeout":   "15s",
+	"log_level":          "info",
+	"garantia_meses":     "12",
+	"garantia_arquivo":   "",
+	"balcao_reparo_pago": "0",
+}
+
+// Carregar monta a configuração a partir dos valores padrão, do arquivo
+// indicado em HELPDESK_CONFIG (YAML ou TOML) e das variáveis HELPDESK_*,
+// nessa ordem de precedência.
+func Carregar() (Config, error) {
+	return CarregarDe(os.LookupEnv)
+}
+
+func CarregarDe(lookupEnv func(string) (string, bool)) (Config, error) {
+	valores := map[string]string{}
+	for chave, valor := range padroes {
+		valores[chave] = valor
+	}
+
+	problemas := map[string]string{}
+	if caminho, ok := lookupEnv(prefixoAmbiente + "CONFIG"); ok && caminho != "" {
+		doArquivo, desconhecidas, err := lerArquivo(caminho)
+		if err != nil {
+			return Config{}, err
+		}
+		for chave, valor := range doArquivo {
+			valores[chave] = valor
+		}
+		problemas = desconhecidas
+	}
+
+	for chave := range padroes {
+		if valor, ok := lookupEnv(prefixoAmbiente + strings.ToUpper(chave)); ok {
+			valores[chave] = valor
+		}
+	}
+
+	return validar(valores, problemas)
+}
+
+// lerArquivo devolve os valores do arquivo e, à parte, as chaves que ele
+// não reconhece, para que sejam relatadas junto dos demais problemas. Uma
+// chave com valor nulo é tratada como ausente.
+func lerArquivo(caminho string) (map[string]string, map[string]string, error) {
+	conteudo, err := os.ReadFile(caminho)
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao ler arquivo de configuração: %w", err)
+	}
+
+	var brutos map[string]any
+	switch strings.ToLower(filepath.Ext(caminho)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(conteudo, &brutos)
+	case ".toml":
+		err = toml.Unmarshal(conteudo, &brutos)
+	default:
+		return nil, nil, fmt.Errorf("formato de configuração não suportado: %s (use .yaml, .yml ou .toml)", caminho)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao interpretar %s: %w", caminho, err)
+	}
+
+	valores := map[string]string{}
+	desconhecidas := map[string]string{}
+	for chave, valor := range brutos {
+		if _, ok := padroes[chave]; !ok {
+			desconhecidas[chave] = "chave desconhecida"
+			continue
+		}
+		if valor != nil {
+			valores[chave] = fmt.Sprint(valor)
+		}
+	}
+	return valores, desconhecidas, nil
+}
+
+func validar(valores map[string]string, problemas map[string]string) (Config, error) {
+	var cfg Config
+
+	cfg.Storage = valores["storage"]
+	if cfg.Storage != "mysql" && cfg.Storage != "memory" {
+		problemas["storage"] = fmt.Sprintf("valor %q inválido (use mysql ou memory)", cfg.Storage)
+	}
+
+	if cfg.Storage == "mysql" {
+		if valores["dsn"] == "" {
+			problemas["dsn"] = "obrigatório quando storage é mysql"
+		} else if dsn, err := mysql.ParseDSN(valores["dsn"]); err != nil {
+			problemas["dsn"] = err.Error()
+		} else {
+			dsn.ParseTime = true
+			cfg.DSN = dsn.FormatDSN()
+		}
+	}
+
+	cfg.ListenAddr = valores["listen_addr"]
+	if _, _, err := net.SplitHostPort(cfg.ListenAddr); err != nil {
+		problemas["listen_addr"] = fmt.Sprintf("endereço %q inválido", cfg.ListenAddr)
+	}
+
+	limite, err := strconv.Atoi(valores["limite_fila"])
+	if err != nil || limite <= 0 {
+		problemas["limite_fila"] = fmt.Sprintf("valor %q inválido (deve ser um inteiro maior que zero)", valores["limite_fila"])
+	}
+	cfg.LimiteFila = limite
+
+	for chave, destino := range map[string]*time.Duration{
+		"read_timeout":     &cfg.ReadTimeout,
+		"write_timeout":    &cfg.WriteTimeout,
+		"shutdown_timeout": &cfg.ShutdownTimeout,
+	} {
+		duracao, err := time.ParseDuration(valores[chave])
+		if err != nil || duracao <= 0 {
+			problemas[chave] = fmt.Sprintf("duração %q inválida (ex.: 15s)", valores[chave])
+		}
+		*destino = duracao
+	}
+
+	if err := cfg.LogLevel.UnmarshalText([]byte(valores["log_level"])); err != nil {
+		problemas["log_level"] = fmt.Sprintf("nível %q inválido (use debug, info, warn ou error)", valores["log_level"])
+	}
+
+	meses, err := strconv.Atoi(valores["garantia_meses"])
+	if err != nil || meses < 0 {
+		problemas["garantia_meses"] = fmt.Sprintf("valor %q inválido (deve ser um inteiro maior ou igual a zero)", valores["garantia_meses"])
+	}
+	cfg.GarantiaMeses = meses
+	cfg.GarantiaArquivo = valores["garantia_arquivo"]
+
+	balcao, err := strconv.ParseInt(valores["balcao_reparo_pago"], 10, 64)
+	if err != nil || balcao < 0 {
+		problemas["balcao_reparo_pago"] = fmt.Sprintf("valor %q inválido (use o ID do balcão ou 0 para desligar)", valores["balcao_reparo_pago"])
+	}
+	cfg.BalcaoReparoPago = balcao
+
+	if len(problemas) > 0 {
+		return Config{}, &ErroConfig{Chaves: problemas}
+	}
+	return cfg, nil
+}
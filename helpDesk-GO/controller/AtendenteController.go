@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/dto"
+	"helpdesk/service"
+	"net/http"
+)
+
+type AtendenteController struct {
+	AtendenteService *service.AtendenteService
+}
+
+func NovoAtendenteController(atendenteService *service.AtendenteService) *AtendenteController {
+	return &AtendenteController{AtendenteService: atendenteService}
+}
+
+func (ac *AtendenteController) CadastrarAtendente(c *gin.Context) {
+	var atendenteDTO dto.CriarAtendenteDTO
+
+	if err := c.ShouldBindJSON(&atendenteDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	atendente, err := ac.AtendenteService.CadastrarAtendente(&atendenteDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Atendente criado com sucesso!", "data": dto.NovaRespostaAtendente(atendente.Atendente)})
+}
+
+func (ac *AtendenteController) ListarAtendentes(c *gin.Context) {
+	atendentes, err := ac.AtendenteService.ListarAtendentes()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaAtendenteDTO, 0, len(atendentes))
+	for _, atendente := range atendentes {
+		resposta = append(resposta, dto.NovaRespostaAtendente(atendente.Atendente))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
+
+func (ac *AtendenteController) DetalharAtendente(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	atendente, err := ac.AtendenteService.DetalharAtendente(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaAtendente(atendente.Atendente))
+}
+
+func (ac *AtendenteController) EditarAtendente(c *gin.Context) {
+	var atendenteDTO dto.EditarAtendenteDTO
+
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := c.ShouldBindJSON(&atendenteDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	atendente, err := ac.AtendenteService.EditarAtendente(id, &atendenteDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaAtendente(atendente.Atendente))
+}
+
+// DesativarAtendente atende o DELETE: o atendente continua referenciado
+// pelos chamados que já atendeu.
+func (ac *AtendenteController) DesativarAtendente(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := ac.AtendenteService.DesativarAtendente(id); err != nil {
+		c.Error(err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
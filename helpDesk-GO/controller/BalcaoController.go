@@ -5,7 +5,6 @@ import (
 	"helpdesk/dto"
 	"helpdesk/service"
 	"net/http"
-	"strconv"
 )
 
 type BalcaoController struct {
@@ -17,41 +16,117 @@ func NewBalcaoController(balcaoService *service.BalcaoService) *BalcaoController
 }
 
 func (bc *BalcaoController) CadastrarBalcao(c *gin.Context) {
-	var balcaoDTO dto.BalcaoDTO
+	var balcaoDTO dto.CriarBalcaoDTO
 
 	if err := c.ShouldBindJSON(&balcaoDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 	saveBalcao, err := bc.BalcaoService.CadastrarBalcao(&balcaoDTO)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Erro ao criar balcão: " + err.Error()})
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusCreated, gin.H{"message": "Balcão criado com sucesso!", "data": saveBalcao})
+	escreverETag(c, saveBalcao.Versao)
+	c.JSON(http.StatusCreated, gin.H{"message": "Balcão criado com sucesso!", "data": dto.NovaRespostaBalcao(saveBalcao.Balcao)})
 }
 
-func (bc *BalcaoController) ListarBalcao(c *gin.Context) {
-	var balcaoDTO dto.BalcaoDTO
+func (bc *BalcaoController) ListarBalcoes(c *gin.Context) {
+	balcoes, err := bc.BalcaoService.ListarBalcoes()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaBalcaoDTO, 0, len(balcoes))
+	for _, balcao := range balcoes {
+		resposta = append(resposta, dto.NovaRespostaBalcao(balcao.Balcao))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
 
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+func (bc *BalcaoController) DetalharBalcao(c *gin.Context) {
+	id, err := idDaRota(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido!"})
+		c.Error(err)
+		return
+	}
+
+	balcao, ocupacao, err := bc.BalcaoService.DetalharBalcao(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	escreverETag(c, balcao.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaBalcaoDetalhada(balcao.Balcao, ocupacao))
+}
+
+func (bc *BalcaoController) EditarBalcao(c *gin.Context) {
+	var balcaoDTO dto.EditarBalcaoDTO
+
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	versao, err := versaoDoIfMatch(c)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 	if err := c.ShouldBindJSON(&balcaoDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos!", "details": err.Error()})
+		c.Error(dadosInvalidos(err))
 		return
 	}
+	balcaoDTO.Versao = versao
 	balcaoAtualizado, err := bc.BalcaoService.EditarBalcao(&balcaoDTO, id)
 	if err != nil {
-		if _, ok := err.(*service.NotFoundError); ok {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Balcão não encontrado"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
+		return
+	}
+	escreverETag(c, balcaoAtualizado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaBalcao(balcaoAtualizado.Balcao))
+}
+
+func (bc *BalcaoController) AtualizarBalcaoParcial(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	versao, err := versaoDoIfMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch dto.PatchBalcaoDTO
+	if err := lerMergePatch(c, &patch, "id", "fila_atendimento"); err != nil {
+		c.Error(err)
+		return
+	}
+	patch.Versao = versao
+
+	balcaoAtualizado, err := bc.BalcaoService.AtualizarBalcaoParcial(id, &patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	escreverETag(c, balcaoAtualizado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaBalcao(balcaoAtualizado.Balcao))
+}
+
+// DesativarBalcao atende o DELETE: o balcão é mantido para o histórico dos
+// chamados, mas deixa de receber novos.
+func (bc *BalcaoController) DesativarBalcao(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if err := bc.BalcaoService.DesativarBalcao(id); err != nil {
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, balcaoAtualizado)
+	c.Status(http.StatusNoContent)
 }
@@ -2,11 +2,10 @@ package controller
 
 import (
 	"github.com/gin-gonic/gin"
-	"helpdesk/Exception"
 	"helpdesk/dto"
+	"helpdesk/entity"
 	"helpdesk/service"
 	"net/http"
-	"strconv"
 )
 
 type ChamadoController struct {
@@ -22,72 +21,189 @@ func NovoChamadoServiceController() *service.ChamadoService {
 }
 
 func (cc *ChamadoController) CriarChamado(c *gin.Context) {
-	var chamadoDTO dto.ChamadoDTO
+	var chamadoDTO dto.CriarChamadoDTO
 
 	if err := c.ShouldBindJSON(&chamadoDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Dados inválidos"})
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
 	chamado, err := cc.ChamadoService.CriarChamado(&chamadoDTO)
 	if err != nil {
-		switch e := err.(type) {
-		case *Exception.ConflictException:
-			c.JSON(http.StatusConflict, gin.H{
-				"message": e.Message,
-				"uri":     e.Uri,
-			})
-		case *Exception.ForbiddenException:
-			c.JSON(http.StatusForbidden, gin.H{
-				"message": e.Message,
-				"uri":     e.Uri,
-			})
-		default:
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Erro interno do servidor: " + err.Error(),
-			})
-		}
-		return
-	}
-
-	c.JSON(http.StatusCreated, chamado)
+		c.Error(err)
+		return
+	}
+
+	escreverETag(c, chamado.Versao)
+	c.JSON(http.StatusCreated, dto.NovaRespostaChamado(chamado.Chamado))
 }
 
+// ListarChamados responde a página como uma lista; o total e os links para
+// as páginas vizinhas vão nos cabeçalhos X-Total-Count e Link.
 func (cc *ChamadoController) ListarChamados(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
-	size, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	consulta, err := lerListagemChamados(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	pagina, err := cc.ChamadoService.BuscarChamados(consulta)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaChamadoDTO, 0, len(pagina.Chamados))
+	for _, chamado := range pagina.Chamados {
+		resposta = append(resposta, dto.NovaRespostaChamado(chamado.Chamado))
+	}
+	escreverPaginacao(c, consulta, pagina.Total, pagina.Proximo, pagina.Anterior)
+	c.JSON(http.StatusOK, resposta)
+}
+
+// ContarPorCategoria atende GET /chamados/por-categoria, aceitando os mesmos
+// filtros da listagem.
+func (cc *ChamadoController) ContarPorCategoria(c *gin.Context) {
+	filtro, err := lerFiltroChamados(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	totais, err := cc.ChamadoService.ContarPorCategoria(filtro)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, totais)
+}
+
+// ListarChamadosDoCliente atende GET /clientes/:id/chamados.
+func (cc *ChamadoController) ListarChamadosDoCliente(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	chamados, err := cc.ChamadoService.ListaChamadosCustomerId(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaChamadoDTO, 0, len(chamados))
+	for _, chamado := range chamados {
+		resposta = append(resposta, dto.NovaRespostaChamado(chamado.Chamado))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
+
+func (cc *ChamadoController) DetalharChamado(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	chamado, err := cc.ChamadoService.ChamadoDetalhado(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	escreverETag(c, chamado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaChamado(chamado.Chamado))
+}
 
-	chamados, err := cc.ChamadoService.ListarChamados(page, size)
+func (cc *ChamadoController) EditarChamados(c *gin.Context) {
+	var chamadoDTO dto.EditarChamadoDTO
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	versao, err := versaoDoIfMatch(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
+		return
+	}
+
+	if err := c.ShouldBindJSON(&chamadoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
 		return
 	}
-	c.JSON(http.StatusOK, chamados)
+	chamadoDTO.Versao = versao
+
+	chamadoAtualizado, err := cc.ChamadoService.EditarChamado(id, &chamadoDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	escreverETag(c, chamadoAtualizado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaChamado(chamadoAtualizado.Chamado))
 }
 
-func (cc *ChamadoController) EditarChamados(c *gin.Context, chamadoService service.ChamadoService) {
-	var chamadoDTO dto.ChamadoDTO
-	id := c.Param("id")
-	idInt64, err := strconv.ParseInt(id, 10, 64)
+func (cc *ChamadoController) AtualizarChamadoParcial(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	versao, err := versaoDoIfMatch(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	var patch dto.PatchChamadoDTO
+	if err := lerMergePatch(c, &patch, "id", "customer_id", "data_creation"); err != nil {
+		c.Error(err)
+		return
+	}
+	patch.Versao = versao
+
+	chamadoAtualizado, err := cc.ChamadoService.AtualizarChamadoParcial(id, &patch)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	escreverETag(c, chamadoAtualizado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaChamado(chamadoAtualizado.Chamado))
+}
+
+func (cc *ChamadoController) AssumirChamado(c *gin.Context) {
+	cc.executarAcao(c, cc.ChamadoService.AssumirChamado)
+}
+
+func (cc *ChamadoController) ResolverChamado(c *gin.Context) {
+	cc.executarAcao(c, cc.ChamadoService.ResolverChamado)
+}
+
+func (cc *ChamadoController) FecharChamado(c *gin.Context) {
+	cc.executarAcao(c, cc.ChamadoService.FecharChamado)
+}
+
+func (cc *ChamadoController) ReabrirChamado(c *gin.Context) {
+	cc.executarAcao(c, cc.ChamadoService.ReabrirChamado)
+}
 
+func (cc *ChamadoController) executarAcao(c *gin.Context, acao func(id, idAtendente int64) (*entity.ChamadoEntity, error)) {
+	id, err := idDaRota(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		c.Error(err)
 		return
 	}
 
-	if err := c.ShouldBind(&chamadoDTO); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	var acaoDTO dto.AcaoChamadoDTO
+	if err := c.ShouldBindJSON(&acaoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
 		return
 	}
 
-	chamadoAtualizado, err := chamadoService.EditarChamado(idInt64, &chamadoDTO)
+	chamado, err := acao(id, acaoDTO.IDAtendente)
 	if err != nil {
-		if err.Error() == "Chamado nao encontrado" {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Erro ao encontrar o Chamado"})
-		} else {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+		c.Error(err)
 		return
 	}
-	c.JSON(http.StatusOK, chamadoAtualizado)
+	escreverETag(c, chamado.Versao)
+	c.JSON(http.StatusOK, dto.NovaRespostaChamado(chamado.Chamado))
 }
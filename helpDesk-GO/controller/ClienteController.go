@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/dto"
+	"helpdesk/service"
+	"net/http"
+)
+
+type ClienteController struct {
+	ClienteService *service.ClienteService
+}
+
+func NovoClienteController(clienteService *service.ClienteService) *ClienteController {
+	return &ClienteController{ClienteService: clienteService}
+}
+
+func (cc *ClienteController) CadastrarCliente(c *gin.Context) {
+	var clienteDTO dto.ClienteDTO
+
+	if err := c.ShouldBindJSON(&clienteDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	cliente, err := cc.ClienteService.CadastrarCliente(&clienteDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Cliente criado com sucesso!", "data": dto.NovaRespostaCliente(cliente.Cliente)})
+}
+
+func (cc *ClienteController) ListarClientes(c *gin.Context) {
+	clientes, err := cc.ClienteService.ListarClientes()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaClienteDTO, 0, len(clientes))
+	for _, cliente := range clientes {
+		resposta = append(resposta, dto.NovaRespostaCliente(cliente.Cliente))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
+
+func (cc *ClienteController) DetalharCliente(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	cliente, err := cc.ClienteService.DetalharCliente(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaCliente(cliente.Cliente))
+}
+
+func (cc *ClienteController) EditarCliente(c *gin.Context) {
+	var clienteDTO dto.ClienteDTO
+
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := c.ShouldBindJSON(&clienteDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	cliente, err := cc.ClienteService.EditarCliente(id, &clienteDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaCliente(cliente.Cliente))
+}
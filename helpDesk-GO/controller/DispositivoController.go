@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/dto"
+	"helpdesk/service"
+	"net/http"
+)
+
+type DispositivoController struct {
+	DispositivoService *service.DispositivoService
+}
+
+func NovoDispositivoController(dispositivoService *service.DispositivoService) *DispositivoController {
+	return &DispositivoController{DispositivoService: dispositivoService}
+}
+
+func (dc *DispositivoController) CadastrarDispositivo(c *gin.Context) {
+	var dispositivoDTO dto.CriarDispositivoDTO
+
+	if err := c.ShouldBindJSON(&dispositivoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	dispositivo, err := dc.DispositivoService.CadastrarDispositivo(&dispositivoDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Dispositivo criado com sucesso!", "data": dto.NovaRespostaDispositivo(dispositivo.Dispositivo)})
+}
+
+func (dc *DispositivoController) DetalharDispositivo(c *gin.Context) {
+	dispositivo, err := dc.DispositivoService.DetalharDispositivo(c.Param("serial"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaDispositivo(dispositivo.Dispositivo))
+}
+
+func (dc *DispositivoController) EditarDispositivo(c *gin.Context) {
+	var dispositivoDTO dto.EditarDispositivoDTO
+
+	if err := c.ShouldBindJSON(&dispositivoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	dispositivo, err := dc.DispositivoService.EditarDispositivo(c.Param("serial"), &dispositivoDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaDispositivo(dispositivo.Dispositivo))
+}
+
+// HistoricoDispositivo atende GET /dispositivos/:serial/historico.
+func (dc *DispositivoController) HistoricoDispositivo(c *gin.Context) {
+	chamados, err := dc.DispositivoService.HistoricoDispositivo(c.Param("serial"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaChamadoDTO, 0, len(chamados))
+	for _, chamado := range chamados {
+		resposta = append(resposta, dto.NovaRespostaChamado(chamado.Chamado))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/Exception"
+	"strconv"
+	"strings"
+)
+
+// O ETag de chamados e balcões é a versão do registro entre aspas.
+func escreverETag(c *gin.Context, versao int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(versao, 10)))
+}
+
+// versaoDoIfMatch lê a versão esperada do cabeçalho If-Match, obrigatório
+// nas edições. "*" aceita qualquer versão e devolve zero.
+func versaoDoIfMatch(c *gin.Context) (int64, error) {
+	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
+	if ifMatch == "" {
+		return 0, &Exception.PrecondicaoObrigatoriaException{
+			Message: "Envie o cabeçalho If-Match com o ETag obtido na última leitura.",
+		}
+	}
+	if ifMatch == "*" {
+		return 0, nil
+	}
+
+	valor, err := strconv.Unquote(strings.TrimPrefix(ifMatch, "W/"))
+	if err != nil {
+		valor = ifMatch
+	}
+	versao, err := strconv.ParseInt(valor, 10, 64)
+	if err != nil || versao <= 0 {
+		return 0, &Exception.ValidationException{
+			Message: "If-Match inválido.",
+			Campos:  map[string]string{"If-Match": "deve ser um ETag devolvido pela API"},
+		}
+	}
+	return versao, nil
+}
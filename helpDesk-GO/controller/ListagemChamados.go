@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/model"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tamanhoPaginaPadrao = 10
+	tamanhoPaginaMaximo = 100
+	// Páginas além desta custam um OFFSET grande demais; use o cursor.
+	paginaMaxima = 10000
+)
+
+// lerListagemChamados converte os parâmetros de consulta de GET /chamados.
+// Todos os erros são reunidos em uma única ValidationException.
+func lerListagemChamados(c *gin.Context) (dto.ListarChamadosDTO, error) {
+	leitor := leitorConsulta{c: c, campos: map[string]string{}}
+	consulta := dto.ListarChamadosDTO{
+		Filtro:    leitor.filtro(),
+		Ordem:     leitor.ordem("ordenar"),
+		PorCursor: c.Query("paginacao") == "cursor" || c.Query("cursor") != "",
+		Cursor:    c.Query("cursor"),
+		Page:      leitor.inteiro("page", 0, 0, paginaMaxima),
+		PageSize:  leitor.inteiro("pageSize", tamanhoPaginaPadrao, 1, tamanhoPaginaMaximo),
+	}
+	if paginacao := c.Query("paginacao"); paginacao != "" && paginacao != "cursor" && paginacao != "pagina" {
+		leitor.campos["paginacao"] = "use cursor ou pagina"
+	}
+	if consulta.PorCursor && c.Query("page") != "" {
+		leitor.campos["page"] = "não pode ser usado com paginação por cursor"
+	}
+
+	if len(leitor.campos) > 0 {
+		return consulta, &Exception.ValidationException{Message: "Parâmetros de consulta inválidos.", Campos: leitor.campos}
+	}
+	return consulta, nil
+}
+
+// lerFiltroChamados lê apenas os filtros de GET /chamados, para consultas
+// que não paginam.
+func lerFiltroChamados(c *gin.Context) (model.FiltroChamados, error) {
+	leitor := leitorConsulta{c: c, campos: map[string]string{}}
+	filtro := leitor.filtro()
+	if len(leitor.campos) > 0 {
+		return filtro, &Exception.ValidationException{Message: "Parâmetros de consulta inválidos.", Campos: leitor.campos}
+	}
+	return filtro, nil
+}
+
+type leitorConsulta struct {
+	c      *gin.Context
+	campos map[string]string
+}
+
+func (l leitorConsulta) filtro() model.FiltroChamados {
+	return model.FiltroChamados{
+		Status:        l.status("status_chamado"),
+		IDBalcao:      l.id("id_balcao"),
+		IDAtendente:   l.id("id_atendente"),
+		UserAtendente: l.c.Query("user_atendente"),
+		CustomerID:    l.id("customer_id"),
+		IDProduto:     l.id("id_produto"),
+		Categoria:     l.c.Query("categoria"),
+		Produto:       l.c.Query("produto"),
+		SerialNumber:  l.c.Query("serial_number"),
+		CriadoDe:      l.data("criado_de", false),
+		CriadoAte:     l.data("criado_ate", true),
+	}
+}
+
+func (l leitorConsulta) inteiro(nome string, padrao, minimo, maximo int) int {
+	texto, ok := l.c.GetQuery(nome)
+	if !ok {
+		return padrao
+	}
+	valor, err := strconv.Atoi(texto)
+	switch {
+	case err != nil:
+		l.campos[nome] = "deve ser um número inteiro"
+	case valor < minimo:
+		l.campos[nome] = "deve ser maior ou igual a " + strconv.Itoa(minimo)
+	case valor > maximo:
+		l.campos[nome] = "deve ser menor ou igual a " + strconv.Itoa(maximo)
+	}
+	return valor
+}
+
+func (l leitorConsulta) id(nome string) int64 {
+	texto := l.c.Query(nome)
+	if texto == "" {
+		return 0
+	}
+	valor, err := strconv.ParseInt(texto, 10, 64)
+	if err != nil || valor <= 0 {
+		l.campos[nome] = "deve ser um número inteiro maior que 0"
+	}
+	return valor
+}
+
+// status aceita vários valores separados por vírgula.
+func (l leitorConsulta) status(nome string) []model.StatusChamado {
+	texto := l.c.Query(nome)
+	if texto == "" {
+		return nil
+	}
+	var lista []model.StatusChamado
+	for _, parte := range strings.Split(texto, ",") {
+		status := model.StatusChamado(strings.ToUpper(strings.TrimSpace(parte)))
+		if !status.Valido() {
+			l.campos[nome] = "status desconhecido: " + parte
+			continue
+		}
+		lista = append(lista, status)
+	}
+	return lista
+}
+
+// data aceita RFC 3339 ou apenas a data. No limite final, a data sozinha
+// inclui o dia inteiro.
+func (l leitorConsulta) data(nome string, fim bool) time.Time {
+	texto := l.c.Query(nome)
+	if texto == "" {
+		return time.Time{}
+	}
+	if valor, err := time.Parse(time.RFC3339, texto); err == nil {
+		return valor
+	}
+	valor, err := time.ParseInLocation(time.DateOnly, texto, time.Local)
+	if err != nil {
+		l.campos[nome] = "use o formato AAAA-MM-DD ou RFC 3339"
+		return time.Time{}
+	}
+	if fim {
+		valor = valor.AddDate(0, 0, 1)
+	}
+	return valor
+}
+
+// ordem lê o campo de ordenação; o prefixo "-" inverte o sentido.
+func (l leitorConsulta) ordem(nome string) model.OrdemChamados {
+	texto := l.c.Query(nome)
+	if texto == "" {
+		return model.OrdemChamados{Campo: model.OrdenarPorID}
+	}
+	ordem := model.OrdemChamados{Campo: model.CampoOrdenacao(strings.TrimPrefix(texto, "-")), Decrescente: strings.HasPrefix(texto, "-")}
+	if !ordem.Campo.Valido() {
+		l.campos[nome] = "campo de ordenação desconhecido"
+	}
+	return ordem
+}
+
+// escreverPaginacao informa o total em X-Total-Count e as páginas vizinhas no
+// cabeçalho Link (RFC 8288), mantendo os demais parâmetros da consulta.
+func escreverPaginacao(c *gin.Context, consulta dto.ListarChamadosDTO, total int64, proximo, anterior string) {
+	c.Header("X-Total-Count", strconv.FormatInt(total, 10))
+
+	var links []string
+	link := func(rel, parametro, valor string) {
+		query := c.Request.URL.Query()
+		query.Del("cursor")
+		query.Del("page")
+		query.Del("paginacao")
+		query.Set(parametro, valor)
+		endereco := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+		links = append(links, "<"+endereco.String()+`>; rel="`+rel+`"`)
+	}
+
+	if consulta.PorCursor {
+		if proximo != "" {
+			link("next", "cursor", proximo)
+		}
+		if anterior != "" {
+			link("prev", "cursor", anterior)
+		}
+	} else {
+		if int64(consulta.Page+1)*int64(consulta.PageSize) < total {
+			link("next", "page", strconv.Itoa(consulta.Page+1))
+		}
+		if consulta.Page > 0 {
+			link("prev", "page", strconv.Itoa(consulta.Page-1))
+		}
+	}
+	if len(links) > 0 {
+		c.Header("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/dto"
+	"helpdesk/service"
+	"net/http"
+)
+
+type ProdutoController struct {
+	ProdutoService *service.ProdutoService
+}
+
+func NovoProdutoController(produtoService *service.ProdutoService) *ProdutoController {
+	return &ProdutoController{ProdutoService: produtoService}
+}
+
+func (pc *ProdutoController) CadastrarProduto(c *gin.Context) {
+	var produtoDTO dto.ProdutoDTO
+
+	if err := c.ShouldBindJSON(&produtoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	produto, err := pc.ProdutoService.CadastrarProduto(&produtoDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"message": "Produto criado com sucesso!", "data": dto.NovaRespostaProduto(produto.Produto)})
+}
+
+func (pc *ProdutoController) ListarProdutos(c *gin.Context) {
+	produtos, err := pc.ProdutoService.ListarProdutos()
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	resposta := make([]dto.RespostaProdutoDTO, 0, len(produtos))
+	for _, produto := range produtos {
+		resposta = append(resposta, dto.NovaRespostaProduto(produto.Produto))
+	}
+	c.JSON(http.StatusOK, resposta)
+}
+
+func (pc *ProdutoController) DetalharProduto(c *gin.Context) {
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	produto, err := pc.ProdutoService.DetalharProduto(id)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaProduto(produto.Produto))
+}
+
+func (pc *ProdutoController) EditarProduto(c *gin.Context) {
+	var produtoDTO dto.ProdutoDTO
+
+	id, err := idDaRota(c)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	if err := c.ShouldBindJSON(&produtoDTO); err != nil {
+		c.Error(dadosInvalidos(err))
+		return
+	}
+	produto, err := pc.ProdutoService.EditarProduto(id, &produtoDTO)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	c.JSON(http.StatusOK, dto.NovaRespostaProduto(produto.Produto))
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"helpdesk/Exception"
+	"io"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const contentTypeMergePatch = "application/merge-patch+json"
+
+func idDaRota(c *gin.Context) (int64, error) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, &Exception.ValidationException{
+			Message: "ID inválido.",
+			Campos:  map[string]string{"id": "deve ser um número inteiro"},
+		}
+	}
+	return id, nil
+}
+
+// lerMergePatch preenche destino, uma struct de campos ponteiro, com um JSON
+// Merge Patch. Campos ausentes ficam nil e campos null recebem o valor zero.
+// Campos imutáveis ou que não existem em destino são rejeitados. O zero de um
+// campo marcado com `patch:"anulavel"` significa remover o valor, por isso ele
+// não passa pelas regras de binding quando vem de um null.
+func lerMergePatch(c *gin.Context, destino any, imutaveis ...string) error {
+	if tipo := c.ContentType(); tipo != contentTypeMergePatch && tipo != binding.MIMEJSON {
+		return &Exception.ValidationException{Message: "Content-Type deve ser " + contentTypeMergePatch + "."}
+	}
+
+	corpo, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return dadosInvalidos(err)
+	}
+	var patch map[string]json.RawMessage
+	if err := json.Unmarshal(corpo, &patch); err != nil || patch == nil {
+		return &Exception.ValidationException{Message: "O patch deve ser um objeto JSON."}
+	}
+
+	alvo := reflect.ValueOf(destino).Elem()
+	indices := camposJSON(alvo.Type())
+	campos := map[string]string{}
+	var nulos []string
+	for nome, valor := range patch {
+		switch {
+		case slices.Contains(imutaveis, nome):
+			campos[nome] = "não pode ser alterado"
+		case indices[nome] == nil:
+			campos[nome] = "campo desconhecido"
+		case bytes.Equal(bytes.TrimSpace(valor), []byte("null")):
+			nulos = append(nulos, nome)
+			delete(patch, nome)
+		}
+	}
+	if len(campos) > 0 {
+		return &Exception.ValidationException{Message: "Dados inválidos.", Campos: campos}
+	}
+
+	restante, _ := json.Marshal(patch)
+	if err := json.Unmarshal(restante, destino); err != nil {
+		return dadosInvalidos(err)
+	}
+	var anulados []reflect.Value
+	for _, nome := range nulos {
+		campo := alvo.FieldByIndex(indices[nome])
+		if alvo.Type().FieldByIndex(indices[nome]).Tag.Get("patch") == "anulavel" {
+			anulados = append(anulados, campo)
+			continue
+		}
+		campo.Set(reflect.New(campo.Type().Elem()))
+	}
+
+	if err := binding.Validator.ValidateStruct(destino); err != nil {
+		return dadosInvalidos(err)
+	}
+	for _, campo := range anulados {
+		campo.Set(reflect.New(campo.Type().Elem()))
+	}
+	return nil
+}
+
+func camposJSON(tipo reflect.Type) map[string][]int {
+	indices := make(map[string][]int, tipo.NumField())
+	for _, campo := range reflect.VisibleFields(tipo) {
+		nome, _, _ := strings.Cut(campo.Tag.Get("json"), ",")
+		if nome != "" && nome != "-" {
+			indices[nome] = campo.Index
+		}
+	}
+	return indices
+}
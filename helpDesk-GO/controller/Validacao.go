@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"helpdesk/Exception"
+	"helpdesk/model"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	registrarValidacoes sync.Once
+	formatoSerial       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]{2,99}$`)
+	formatoLogin        = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{1,63}$`)
+	formatoTelefone     = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{7,19}$`)
+)
+
+// RegistrarValidacoes adiciona ao validador do gin as regras próprias da API
+// e faz os erros usarem o nome do campo no JSON:
+//
+//   - serial: letras, números e hífens, de 3 a 100 caracteres
+//   - login: letras, números, pontos, hífens e sublinhados, de 2 a 64
+//   - documento: CPF ou CNPJ com dígitos verificadores válidos
+//   - telefone: + opcional e de 8 a 20 dígitos, espaços, parênteses ou hífens
+//   - status_chamado: um dos status conhecidos de chamado
+func RegistrarValidacoes() {
+	registrarValidacoes.Do(func() {
+		validate, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			return
+		}
+
+		validate.RegisterTagNameFunc(func(campo reflect.StructField) string {
+			nome, _, _ := strings.Cut(campo.Tag.Get("json"), ",")
+			if nome == "-" {
+				return ""
+			}
+			return nome
+		})
+		validate.RegisterValidation("serial", func(fl validator.FieldLevel) bool {
+			return formatoSerial.MatchString(fl.Field().String())
+		})
+		validate.RegisterValidation("login", func(fl validator.FieldLevel) bool {
+			return formatoLogin.MatchString(fl.Field().String())
+		})
+		validate.RegisterValidation("documento", func(fl validator.FieldLevel) bool {
+			return model.DocumentoValido(fl.Field().String())
+		})
+		validate.RegisterValidation("telefone", func(fl validator.FieldLevel) bool {
+			return formatoTelefone.MatchString(fl.Field().String())
+		})
+		validate.RegisterValidation("status_chamado", func(fl validator.FieldLevel) bool {
+			return model.StatusChamado(fl.Field().String()).Valido()
+		})
+	})
+}
+
+// dadosInvalidos converte o erro do bind em uma ValidationException com uma
+// mensagem por campo.
+func dadosInvalidos(err error) error {
+	var (
+		validacao validator.ValidationErrors
+		tipo      *json.UnmarshalTypeError
+		sintaxe   *json.SyntaxError
+	)
+	switch {
+	case errors.As(err, &validacao):
+		campos := make(map[string]string, len(validacao))
+		for _, fe := range validacao {
+			campos[fe.Field()] = mensagemValidacao(fe)
+		}
+		return &Exception.ValidationException{Message: "Dados inválidos.", Campos: campos}
+	case errors.As(err, &tipo):
+		return &Exception.ValidationException{
+			Message: "Dados inválidos.",
+			Campos:  map[string]string{tipo.Field: fmt.Sprintf("deve ser do tipo %s", tipo.Type)},
+		}
+	case errors.As(err, &sintaxe):
+		return &Exception.ValidationException{Message: "JSON malformado."}
+	default:
+		return &Exception.ValidationException{Message: "Dados inválidos: " + err.Error()}
+	}
+}
+
+func mensagemValidacao(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required", "required_without":
+		return "obrigatório"
+	case "gt":
+		return "deve ser maior que " + fe.Param()
+	case "gte":
+		return "deve ser maior ou igual a " + fe.Param()
+	case "max":
+		return "deve ter no máximo " + fe.Param() + " caracteres"
+	case "serial":
+		return "formato inválido (use de 3 a 100 letras, números ou hífens)"
+	case "login":
+		return "formato inválido (use de 2 a 64 letras, números, pontos, hífens ou sublinhados)"
+	case "documento":
+		return "CPF ou CNPJ inválido"
+	case "email":
+		return "e-mail inválido"
+	case "telefone":
+		return "telefone inválido"
+	case "status_chamado":
+		return "status desconhecido"
+	case "datetime":
+		return "data inválida (use o formato AAAA-MM-DD)"
+	default:
+		return "inválido (" + fe.Tag() + ")"
+	}
+}
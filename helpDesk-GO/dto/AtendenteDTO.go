@@ -0,0 +1,41 @@
+package dto
+
+import "helpdesk/model"
+
+// CriarAtendenteDTO cadastra um atendente ativo. O login identifica o
+// atendente nos chamados e não muda depois do cadastro.
+type CriarAtendenteDTO struct {
+	Login       string   `json:"login" binding:"required,login"`
+	Nome        string   `json:"nome" binding:"required,max=255"`
+	Habilidades []string `json:"habilidades" binding:"omitempty,dive,required,max=100"`
+}
+
+// EditarAtendenteDTO substitui nome e habilidades. Ativo ausente mantém a
+// situação atual do atendente.
+type EditarAtendenteDTO struct {
+	Nome        string   `json:"nome" binding:"required,max=255"`
+	Ativo       *bool    `json:"ativo"`
+	Habilidades []string `json:"habilidades" binding:"omitempty,dive,required,max=100"`
+}
+
+type RespostaAtendenteDTO struct {
+	ID          int64    `json:"id"`
+	Login       string   `json:"login"`
+	Nome        string   `json:"nome"`
+	Ativo       bool     `json:"ativo"`
+	Habilidades []string `json:"habilidades"`
+}
+
+func NovaRespostaAtendente(atendente model.Atendente) RespostaAtendenteDTO {
+	habilidades := atendente.Habilidades
+	if habilidades == nil {
+		habilidades = []string{}
+	}
+	return RespostaAtendenteDTO{
+		ID:          atendente.ID,
+		Login:       atendente.Login,
+		Nome:        atendente.Nome,
+		Ativo:       atendente.Ativo,
+		Habilidades: habilidades,
+	}
+}
@@ -2,6 +2,69 @@ package dto
 
 import "helpdesk/model"
 
-type BalcaoDTO struct {
-	model.Balcao
+// CriarBalcaoDTO e EditarBalcaoDTO não expõem a fila de atendimento, que é
+// mantida pelo serviço de chamados, nem o nome do atendente, que vem do
+// cadastro de atendentes. Capacidade zero usa o padrão configurado na criação
+// e mantém a atual na edição.
+type CriarBalcaoDTO struct {
+	IDAtendente int64 `json:"id_atendente" binding:"required,gt=0"`
+	Capacidade  int   `json:"capacidade" binding:"gte=0"`
+}
+
+// Versao vem do If-Match da requisição; zero edita sem conferir a versão.
+// Ativo ausente mantém a situação atual do balcão.
+type EditarBalcaoDTO struct {
+	IDAtendente int64 `json:"id_atendente" binding:"required,gt=0"`
+	Capacidade  int   `json:"capacidade" binding:"gte=0"`
+	Ativo       *bool `json:"ativo"`
+	Versao      int64 `json:"-"`
+}
+
+// PatchBalcaoDTO recebe um JSON Merge Patch, como PatchChamadoDTO.
+type PatchBalcaoDTO struct {
+	IDAtendente *int64 `json:"id_atendente" binding:"omitnil,gt=0"`
+	Capacidade  *int   `json:"capacidade" binding:"omitnil,gt=0"`
+	Ativo       *bool  `json:"ativo"`
+	Versao      int64  `json:"-"`
+}
+
+type RespostaBalcaoDTO struct {
+	ID              int64              `json:"id"`
+	IDAtendente     int64              `json:"id_atendente"`
+	NomeAtendente   string             `json:"nome_atendente"`
+	FilaAtendimento int                `json:"fila_atendimento"`
+	Capacidade      int                `json:"capacidade"`
+	Ativo           bool               `json:"ativo"`
+	Versao          int64              `json:"versao"`
+	Ocupacao        *OcupacaoBalcaoDTO `json:"ocupacao,omitempty"`
+}
+
+type OcupacaoBalcaoDTO struct {
+	EmAtendimento int   `json:"em_atendimento"`
+	Capacidade    int   `json:"capacidade"`
+	VagasLivres   int   `json:"vagas_livres"`
+	Aguardando    int64 `json:"aguardando"`
+}
+
+func NovaRespostaBalcao(balcao model.Balcao) RespostaBalcaoDTO {
+	return RespostaBalcaoDTO{
+		ID:              balcao.ID,
+		IDAtendente:     balcao.IDAtendente,
+		NomeAtendente:   balcao.NomeAtendente,
+		FilaAtendimento: balcao.FilaAtendimento,
+		Capacidade:      balcao.Capacidade,
+		Ativo:           balcao.Ativo,
+		Versao:          balcao.Versao,
+	}
+}
+
+func NovaRespostaBalcaoDetalhada(balcao model.Balcao, ocupacao model.OcupacaoBalcao) RespostaBalcaoDTO {
+	resposta := NovaRespostaBalcao(balcao)
+	resposta.Ocupacao = &OcupacaoBalcaoDTO{
+		EmAtendimento: ocupacao.EmAtendimento,
+		Capacidade:    ocupacao.Capacidade,
+		VagasLivres:   ocupacao.VagasLivres,
+		Aguardando:    ocupacao.Aguardando,
+	}
+	return resposta
 }
@@ -2,16 +2,122 @@ package dto
 
 import (
 	"helpdesk/model"
+	"time"
 )
 
-type ChamadoDTO struct {
-	model.Chamado
+// CriarChamadoDTO traz apenas o que o cliente informa ao abrir um chamado;
+// ID, datas, status e atendente são definidos pelo servidor. O balcão pode
+// ser omitido quando o produto do catálogo tem balcão padrão.
+type CriarChamadoDTO struct {
+	CustomerID   int64  `json:"customer_id" binding:"required,gt=0"`
+	DeviceID     string `json:"device_id" binding:"max=100"`
+	SerialNumber string `json:"serial_number" binding:"required,serial"`
+	Chamado      string `json:"chamado"`
+	IDBalcao     int64  `json:"id_balcao" binding:"required_without=IDProduto,omitempty,gt=0"`
+	Motivo       string `json:"motivo"`
+	IDProduto    int64  `json:"id_produto" binding:"omitempty,gt=0"`
+	Produto      string `json:"produto" binding:"max=255"`
+	UserClient   string `json:"user_client" binding:"max=255"`
 }
-type StatusChamado int
 
-const (
-	Aberto StatusChamado = iota
-	EmAndamento
-	Resolvido
-	Fechado
-)
+// EditarChamadoDTO substitui os dados editáveis de um chamado. O balcão e o
+// atendente só mudam pelas ações do chamado. Versao vem do If-Match da
+// requisição; zero edita sem conferir a versão.
+type EditarChamadoDTO struct {
+	CustomerID    int64               `json:"customer_id" binding:"required,gt=0"`
+	DeviceID      string              `json:"device_id" binding:"max=100"`
+	SerialNumber  string              `json:"serial_number" binding:"required,serial"`
+	Chamado       string              `json:"chamado"`
+	StatusChamado model.StatusChamado `json:"status_chamado" binding:"omitempty,status_chamado"`
+	Motivo        string              `json:"motivo"`
+	IDProduto     int64               `json:"id_produto" binding:"omitempty,gt=0"`
+	Produto       string              `json:"produto" binding:"max=255"`
+	UserClient    string              `json:"user_client" binding:"max=255"`
+	Versao        int64               `json:"-"`
+}
+
+// PatchChamadoDTO recebe um JSON Merge Patch (RFC 7396): só os campos
+// presentes no corpo são alterados e null limpa o campo. ID, customer_id e
+// data_creation são imutáveis e não fazem parte do patch.
+type PatchChamadoDTO struct {
+	DeviceID      *string              `json:"device_id" binding:"omitnil,max=100"`
+	SerialNumber  *string              `json:"serial_number" binding:"omitnil,serial"`
+	Chamado       *string              `json:"chamado"`
+	StatusChamado *model.StatusChamado `json:"status_chamado" binding:"omitnil,status_chamado"`
+	Motivo        *string              `json:"motivo"`
+	IDProduto     *int64               `json:"id_produto" binding:"omitnil,gt=0" patch:"anulavel"`
+	Produto       *string              `json:"produto" binding:"omitnil,max=255"`
+	UserClient    *string              `json:"user_client" binding:"omitnil,max=255"`
+	Versao        int64                `json:"-"`
+}
+
+// AcaoChamadoDTO identifica o atendente que executa a ação.
+type AcaoChamadoDTO struct {
+	IDAtendente int64 `json:"id_atendente" binding:"required,gt=0"`
+}
+
+type RespostaChamadoDTO struct {
+	ID             int64                `json:"id"`
+	CustomerID     int64                `json:"customer_id"`
+	DataCreation   time.Time            `json:"data_creation"`
+	DataResolution *time.Time           `json:"data_resolution,omitempty"`
+	DeviceID       string               `json:"device_id"`
+	SerialNumber   string               `json:"serial_number"`
+	Chamado        string               `json:"chamado"`
+	StatusChamado  model.StatusChamado  `json:"status_chamado"`
+	IDBalcao       int64                `json:"id_balcao"`
+	Motivo         string               `json:"motivo"`
+	IDProduto      int64                `json:"id_produto,omitempty"`
+	Produto        string               `json:"produto"`
+	Garantia       model.StatusGarantia `json:"garantia"`
+	UserClient     string               `json:"user_client"`
+	IDAtendente    int64                `json:"id_atendente,omitempty"`
+	UserAtendente  string               `json:"user_atendente"`
+	UserUltimaAcao string               `json:"user_ultima_acao"`
+	PosicaoFila    int                  `json:"posicao_fila,omitempty"`
+	Versao         int64                `json:"versao"`
+	Balcao         *RespostaBalcaoDTO   `json:"balcao,omitempty"`
+}
+
+func NovaRespostaChamado(chamado model.Chamado) RespostaChamadoDTO {
+	resposta := RespostaChamadoDTO{
+		ID:             chamado.ID,
+		CustomerID:     chamado.CustomerID,
+		DataCreation:   chamado.DataCreation,
+		DeviceID:       chamado.DeviceID,
+		SerialNumber:   chamado.SerialNumber,
+		Chamado:        chamado.Chamado,
+		StatusChamado:  chamado.StatusChamado,
+		IDBalcao:       chamado.IDBalcao,
+		Motivo:         chamado.Motivo,
+		IDProduto:      chamado.IDProduto,
+		Produto:        chamado.Produto,
+		Garantia:       chamado.Garantia,
+		UserClient:     chamado.UserClient,
+		IDAtendente:    chamado.IDAtendente,
+		UserAtendente:  chamado.UserAtendente,
+		UserUltimaAcao: chamado.UserUltimaAcao,
+		PosicaoFila:    chamado.PosicaoFila,
+		Versao:         chamado.Versao,
+	}
+	if !chamado.DataResolution.IsZero() {
+		dataResolution := chamado.DataResolution
+		resposta.DataResolution = &dataResolution
+	}
+	if chamado.Balcao != nil {
+		balcao := NovaRespostaBalcao(*chamado.Balcao)
+		resposta.Balcao = &balcao
+	}
+	return resposta
+}
+
+// ListarChamadosDTO reúne os parâmetros de GET /chamados. Com PorCursor a
+// página é definida por Cursor (vazio na primeira página); senão por Page.
+type ListarChamadosDTO struct {
+	Filtro    model.FiltroChamados
+	Ordem     model.OrdemChamados
+	PorCursor bool
+	Cursor    string
+	Page      int
+	PageSize  int
+}
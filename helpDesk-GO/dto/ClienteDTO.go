@@ -0,0 +1,30 @@
+package dto
+
+import "helpdesk/model"
+
+// ClienteDTO é usado tanto no cadastro quanto na edição, que substitui todos
+// os dados do cliente. O documento aceita CPF ou CNPJ, com ou sem pontuação.
+type ClienteDTO struct {
+	Nome      string `json:"nome" binding:"required,max=255"`
+	Documento string `json:"documento" binding:"required,documento"`
+	Email     string `json:"email" binding:"omitempty,email,max=255"`
+	Telefone  string `json:"telefone" binding:"omitempty,telefone"`
+}
+
+type RespostaClienteDTO struct {
+	ID        int64  `json:"id"`
+	Nome      string `json:"nome"`
+	Documento string `json:"documento"`
+	Email     string `json:"email"`
+	Telefone  string `json:"telefone"`
+}
+
+func NovaRespostaCliente(cliente model.Cliente) RespostaClienteDTO {
+	return RespostaClienteDTO{
+		ID:        cliente.ID,
+		Nome:      cliente.Nome,
+		Documento: cliente.Documento,
+		Email:     cliente.Email,
+		Telefone:  cliente.Telefone,
+	}
+}
@@ -0,0 +1,43 @@
+package dto
+
+import "helpdesk/model"
+
+// FormatoDataCompra é o formato da data de compra nos DTOs de dispositivo.
+const FormatoDataCompra = "2006-01-02"
+
+// CriarDispositivoDTO cadastra um dispositivo. O serial identifica o
+// dispositivo e não muda depois do cadastro.
+type CriarDispositivoDTO struct {
+	SerialNumber string `json:"serial_number" binding:"required,serial"`
+	Produto      string `json:"produto" binding:"max=255"`
+	CustomerID   int64  `json:"customer_id" binding:"required,gt=0"`
+	DataCompra   string `json:"data_compra" binding:"omitempty,datetime=2006-01-02"`
+}
+
+// EditarDispositivoDTO substitui produto, dono e data de compra.
+type EditarDispositivoDTO struct {
+	Produto    string `json:"produto" binding:"max=255"`
+	CustomerID int64  `json:"customer_id" binding:"required,gt=0"`
+	DataCompra string `json:"data_compra" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type RespostaDispositivoDTO struct {
+	ID           int64  `json:"id"`
+	SerialNumber string `json:"serial_number"`
+	Produto      string `json:"produto"`
+	CustomerID   int64  `json:"customer_id"`
+	DataCompra   string `json:"data_compra,omitempty"`
+}
+
+func NovaRespostaDispositivo(dispositivo model.Dispositivo) RespostaDispositivoDTO {
+	resposta := RespostaDispositivoDTO{
+		ID:           dispositivo.ID,
+		SerialNumber: dispositivo.SerialNumber,
+		Produto:      dispositivo.Produto,
+		CustomerID:   dispositivo.CustomerID,
+	}
+	if !dispositivo.DataCompra.IsZero() {
+		resposta.DataCompra = dispositivo.DataCompra.Format(FormatoDataCompra)
+	}
+	return resposta
+}
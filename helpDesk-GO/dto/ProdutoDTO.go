@@ -0,0 +1,31 @@
+package dto
+
+import "helpdesk/model"
+
+// ProdutoDTO é usado tanto no cadastro quanto na edição, que substitui todos
+// os dados do produto. Sem id_balcao_padrao, os chamados do produto precisam
+// informar o balcão.
+type ProdutoDTO struct {
+	SKU            string `json:"sku" binding:"required,serial"`
+	Nome           string `json:"nome" binding:"required,max=255"`
+	Categoria      string `json:"categoria" binding:"required,max=100"`
+	IDBalcaoPadrao int64  `json:"id_balcao_padrao" binding:"omitempty,gt=0"`
+}
+
+type RespostaProdutoDTO struct {
+	ID             int64  `json:"id"`
+	SKU            string `json:"sku"`
+	Nome           string `json:"nome"`
+	Categoria      string `json:"categoria"`
+	IDBalcaoPadrao int64  `json:"id_balcao_padrao,omitempty"`
+}
+
+func NovaRespostaProduto(produto model.Produto) RespostaProdutoDTO {
+	return RespostaProdutoDTO{
+		ID:             produto.ID,
+		SKU:            produto.SKU,
+		Nome:           produto.Nome,
+		Categoria:      produto.Categoria,
+		IDBalcaoPadrao: produto.IDBalcaoPadrao,
+	}
+}
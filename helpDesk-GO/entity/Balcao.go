@@ -9,4 +9,5 @@ type NewBalcaoEntity struct {
 	ID              int64  `json:"id"`
 	NomeAtendente   string `json:"nome_atendente"`
 	FilaAtendimento int    `json:"fila_atendimento"`
+	Capacidade      int    `json:"capacidade"`
 }
@@ -9,35 +9,49 @@ type ChamadoEntity struct {
 	model.Chamado
 }
 
-type StatusChamado int
-
-const (
-	Aberto StatusChamado = iota
-	EmAndamento
-	Resolvido
-	Fechado
-)
-
-func (c *ChamadoEntity) AlterarChamado(dto *dto.ChamadoDTO) {
+func (c *ChamadoEntity) AlterarChamado(dto *dto.EditarChamadoDTO) {
 	c.CustomerID = dto.CustomerID
-	c.DataCreation = dto.DataCreation
-	c.DataResolution = dto.DataResolution
 	c.DeviceID = dto.DeviceID
 	c.SerialNumber = dto.SerialNumber
-	c.StatusChamado = dto.StatusChamado
-	c.IDBalcao = dto.IDBalcao
+	c.Chamado.Chamado = dto.Chamado
 	c.Motivo = dto.Motivo
+	c.IDProduto = dto.IDProduto
 	c.Produto = dto.Produto
 	c.UserClient = dto.UserClient
-	c.UserAtendente = dto.UserAtendente
+}
+
+// AplicarPatch altera apenas os campos informados no patch. O status é
+// tratado pelo serviço, que valida a transição.
+func (c *ChamadoEntity) AplicarPatch(patch *dto.PatchChamadoDTO) {
+	if patch.DeviceID != nil {
+		c.DeviceID = *patch.DeviceID
+	}
+	if patch.SerialNumber != nil {
+		c.SerialNumber = *patch.SerialNumber
+	}
+	if patch.Chamado != nil {
+		c.Chamado.Chamado = *patch.Chamado
+	}
+	if patch.Motivo != nil {
+		c.Motivo = *patch.Motivo
+	}
+	if patch.IDProduto != nil {
+		c.IDProduto = *patch.IDProduto
+	}
+	if patch.Produto != nil {
+		c.Produto = *patch.Produto
+	}
+	if patch.UserClient != nil {
+		c.UserClient = *patch.UserClient
+	}
 }
 
 type ChamadoEntity1 struct {
-	ID               int64         `json:"id"`
-	CustomerID       int64         `json:"customer_id"`
-	SerialNumber     string        `json:"serial_number"`
-	Produto          string        `json:"produto"`
-	StatusChamado    StatusChamado `json:"estado"`
-	UsuarioAtendente string        `json:"usuario_atendente"`
-	Balcao           model.Balcao  `json:"balcao"`
+	ID               int64               `json:"id"`
+	CustomerID       int64               `json:"customer_id"`
+	SerialNumber     string              `json:"serial_number"`
+	Produto          string              `json:"produto"`
+	StatusChamado    model.StatusChamado `json:"estado"`
+	UsuarioAtendente string              `json:"usuario_atendente"`
+	Balcao           model.Balcao        `json:"balcao"`
 }
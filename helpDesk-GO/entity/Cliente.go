@@ -0,0 +1,7 @@
+package entity
+
+import "helpdesk/model"
+
+type ClienteEntity struct {
+	model.Cliente
+}
@@ -1,7 +1,19 @@
 package entity
 
+import "time"
+
+// ListaAtendimento registra a passagem de um chamado por um balcão: quando
+// ele ocupou uma vaga (DataEntrada), quando foi assumido (DataInicio) e quando
+// liberou a vaga (DataFim). Datas ainda não alcançadas ficam zeradas.
 type ListaAtendimento struct {
-	ID      int64 `json:"ID"`
-	Chamado *ChamadoEntity
-	Balcao  *BalcaoEntity
+	ID          int64 `json:"ID"`
+	Chamado     *ChamadoEntity
+	Balcao      *BalcaoEntity
+	DataEntrada time.Time
+	DataInicio  time.Time
+	DataFim     time.Time
+}
+
+func (l *ListaAtendimento) Aberto() bool {
+	return l.DataFim.IsZero()
 }
@@ -1,25 +1,146 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
+	"helpdesk/config"
+	"helpdesk/controller"
+	"helpdesk/migration"
+	"helpdesk/repository"
+	"helpdesk/server"
+	"helpdesk/service"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	dsn := "lesbarros:Cookie@leo18@tcp(127.0.0.1:3306)/helpdesk"
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
+}
 
-	db, err := sql.Open("mysql", dsn)
+func run(args []string) error {
+	cfg, err := config.Carregar()
 	if err != nil {
-		log.Fatal("Erro ao conectar ao banco de dados:", err)
+		return err
 	}
-	defer db.Close()
 
-	if err = db.Ping(); err != nil {
-		log.Fatal("Erro ao conectar ao banco de dados:", err)
+	slog.SetLogLoggerLevel(cfg.LogLevel)
+	if cfg.LogLevel > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
 	}
 
-	fmt.Println("Conexão com o banco de dados MySQL estabelecida com sucesso!")
+	var unidade repository.UnidadeDeTrabalho
+
+	switch cfg.Storage {
+	case "memory":
+		if len(args) > 0 && args[0] == "migrate" {
+			return fmt.Errorf("o comando migrate exige o armazenamento mysql")
+		}
+
+		unidade = repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+		fmt.Println("Usando armazenamento em memória.")
+	case "mysql":
+		db, err := sql.Open("mysql", cfg.DSN)
+		if err != nil {
+			return fmt.Errorf("Erro ao conectar ao banco de dados: %w", err)
+		}
+		defer db.Close()
+
+		if err = db.Ping(); err != nil {
+			return fmt.Errorf("Erro ao conectar ao banco de dados: %w", err)
+		}
+
+		fmt.Println("Conexão com o banco de dados MySQL estabelecida com sucesso!")
 
+		migrator, err := migration.NovoMigrator(db)
+		if err != nil {
+			return err
+		}
+
+		if len(args) > 0 && args[0] == "migrate" {
+			return executarMigrate(migrator, args[1:])
+		}
+
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+
+		unidade = repository.NovaUnidadeDeTrabalhoSQL(db)
+	}
+
+	repos := unidade.Repositorios()
+	chamadoService := service.NovoChamadoServiceTransacional(unidade)
+	chamadoService.LimiteAtendimentos = cfg.LimiteFila
+	chamadoService.Garantia = &service.GarantiaPorDataCompra{Dispositivos: repos.Dispositivos, Meses: cfg.GarantiaMeses}
+	if cfg.GarantiaArquivo != "" {
+		garantias, err := service.CarregarGarantiaArquivo(cfg.GarantiaArquivo, chamadoService.Garantia)
+		if err != nil {
+			return err
+		}
+		chamadoService.Garantia = garantias
+	}
+	if cfg.BalcaoReparoPago != 0 {
+		chamadoService.RegrasGarantia = append(chamadoService.RegrasGarantia, service.EncaminharForaDaGarantia(cfg.BalcaoReparoPago))
+	}
+	balcaoService := service.NovoBalcaoServiceTransacional(unidade)
+	balcaoService.CapacidadePadrao = cfg.LimiteFila
+	atendenteService := service.NovoAtendenteServiceTransacional(unidade)
+	clienteService := service.NovoClienteService(repos.Clientes)
+	dispositivoService := service.NovoDispositivoService(repos.Dispositivos, repos.Clientes, repos.Chamados)
+	produtoService := service.NovoProdutoService(repos.Produtos, repos.Balcoes)
+
+	chamadoController := controller.NovoChamadoController(chamadoService)
+	balcaoController := controller.NewBalcaoController(balcaoService)
+	atendenteController := controller.NovoAtendenteController(atendenteService)
+	clienteController := controller.NovoClienteController(clienteService)
+	dispositivoController := controller.NovoDispositivoController(dispositivoService)
+	produtoController := controller.NovoProdutoController(produtoService)
+
+	router := server.NovoRouter(chamadoController, balcaoController, atendenteController, clienteController, dispositivoController, produtoController)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opcoes := server.Opcoes{
+		Addr:            cfg.ListenAddr,
+		ReadTimeout:     cfg.ReadTimeout,
+		WriteTimeout:    cfg.WriteTimeout,
+		ShutdownTimeout: cfg.ShutdownTimeout,
+	}
+	return server.NovoServer(opcoes, router).Run(ctx)
+}
+
+func executarMigrate(migrator *migration.Migrator, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("uso: helpdesk migrate up|down|status")
+	}
+
+	switch args[0] {
+	case "up":
+		return migrator.Up()
+	case "down":
+		return migrator.Down()
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			if s.Aplicada {
+				fmt.Printf("%04d_%s\taplicada em %s\n", s.Versao, s.Nome, s.AplicadaEm.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("%04d_%s\tpendente\n", s.Versao, s.Nome)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("comando migrate desconhecido: %s", args[0])
+	}
 }
@@ -0,0 +1,299 @@
+package migration
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed sql/*.sql
+var arquivos embed.FS
+
+type Migracao struct {
+	Versao int64
+	Nome   string
+	Up     string
+	Down   string
+}
+
+type StatusMigracao struct {
+	Versao     int64
+	Nome       string
+	Aplicada   bool
+	AplicadaEm time.Time
+}
+
+type Migrator struct {
+	db        *sql.DB
+	migracoes []Migracao
+}
+
+func NovoMigrator(db *sql.DB) (*Migrator, error) {
+	migracoes, err := CarregarMigracoes(arquivos)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migracoes: migracoes}, nil
+}
+
+// CarregarMigracoes lê os pares NNNN_nome.up.sql / NNNN_nome.down.sql do
+// diretório sql e os devolve ordenados pela versão.
+func CarregarMigracoes(fsys fs.FS) ([]Migracao, error) {
+	entradas, err := fs.ReadDir(fsys, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler migrações: %w", err)
+	}
+
+	porVersao := map[int64]*Migracao{}
+	for _, entrada := range entradas {
+		nomeArquivo := entrada.Name()
+		var direcao string
+		switch {
+		case strings.HasSuffix(nomeArquivo, ".up.sql"):
+			direcao = "up"
+		case strings.HasSuffix(nomeArquivo, ".down.sql"):
+			direcao = "down"
+		default:
+			return nil, fmt.Errorf("arquivo de migração inválido: %s", nomeArquivo)
+		}
+
+		base := strings.TrimSuffix(nomeArquivo, "."+direcao+".sql")
+		prefixo, nome, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("arquivo de migração sem nome: %s", nomeArquivo)
+		}
+		versao, err := strconv.ParseInt(prefixo, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("versão inválida na migração %s: %w", nomeArquivo, err)
+		}
+
+		conteudo, err := fs.ReadFile(fsys, "sql/"+nomeArquivo)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler migração %s: %w", nomeArquivo, err)
+		}
+
+		migracao, existe := porVersao[versao]
+		if !existe {
+			migracao = &Migracao{Versao: versao, Nome: nome}
+			porVersao[versao] = migracao
+		} else if migracao.Nome != nome {
+			return nil, fmt.Errorf("versão %d duplicada: %s e %s", versao, migracao.Nome, nome)
+		}
+
+		if direcao == "up" {
+			migracao.Up = string(conteudo)
+		} else {
+			migracao.Down = string(conteudo)
+		}
+	}
+
+	migracoes := make([]Migracao, 0, len(porVersao))
+	for _, migracao := range porVersao {
+		if migracao.Up == "" || migracao.Down == "" {
+			return nil, fmt.Errorf("migração %04d_%s precisa de arquivos up e down", migracao.Versao, migracao.Nome)
+		}
+		migracoes = append(migracoes, *migracao)
+	}
+	sort.Slice(migracoes, func(i, j int) bool { return migracoes[i].Versao < migracoes[j].Versao })
+	return migracoes, nil
+}
+
+// Up aplica as migrações pendentes em ordem. Cada migração é executada e
+// registrada em schema_migrations na mesma transação. O MySQL confirma
+// comandos DDL na hora, então só migrações de dados são desfeitas por
+// inteiro quando um comando falha.
+func (m *Migrator) Up() error {
+	aplicadas, err := m.aplicadas()
+	if err != nil {
+		return err
+	}
+
+	for _, migracao := range m.migracoes {
+		if _, ok := aplicadas[migracao.Versao]; ok {
+			continue
+		}
+		err := m.emTransacao(func(tx *sql.Tx) error {
+			if err := executar(tx, migracao.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec("INSERT INTO schema_migrations (version, nome, applied_at) VALUES (?, ?, ?)",
+				migracao.Versao, migracao.Nome, time.Now())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao aplicar migração %04d_%s: %w", migracao.Versao, migracao.Nome, err)
+		}
+	}
+	return nil
+}
+
+// Down reverte a última migração aplicada e apaga o registro dela na mesma
+// transação, com a mesma ressalva de Up sobre DDL.
+func (m *Migrator) Down() error {
+	aplicadas, err := m.aplicadas()
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migracoes) - 1; i >= 0; i-- {
+		migracao := m.migracoes[i]
+		if _, ok := aplicadas[migracao.Versao]; !ok {
+			continue
+		}
+		err := m.emTransacao(func(tx *sql.Tx) error {
+			if err := executar(tx, migracao.Down); err != nil {
+				return err
+			}
+			_, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", migracao.Versao)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("erro ao reverter migração %04d_%s: %w", migracao.Versao, migracao.Nome, err)
+		}
+		return nil
+	}
+	return nil
+}
+
+func (m *Migrator) Status() ([]StatusMigracao, error) {
+	aplicadas, err := m.aplicadas()
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]StatusMigracao, 0, len(m.migracoes))
+	for _, migracao := range m.migracoes {
+		aplicadaEm, ok := aplicadas[migracao.Versao]
+		status = append(status, StatusMigracao{
+			Versao:     migracao.Versao,
+			Nome:       migracao.Nome,
+			Aplicada:   ok,
+			AplicadaEm: aplicadaEm,
+		})
+	}
+	return status, nil
+}
+
+func (m *Migrator) aplicadas() (map[int64]time.Time, error) {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	    version    BIGINT       NOT NULL,
+	    nome       VARCHAR(255) NOT NULL,
+	    applied_at DATETIME     NOT NULL,
+	    PRIMARY KEY (version)
+	) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4`)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar tabela schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.Query("SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao consultar schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	aplicadas := map[int64]time.Time{}
+	for rows.Next() {
+		var versao int64
+		var aplicadaEm time.Time
+		if err := rows.Scan(&versao, &aplicadaEm); err != nil {
+			return nil, err
+		}
+		aplicadas[versao] = aplicadaEm
+	}
+	return aplicadas, rows.Err()
+}
+
+func (m *Migrator) emTransacao(fn func(tx *sql.Tx) error) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func executar(tx *sql.Tx, script string) error {
+	for _, comando := range DividirComandos(script) {
+		if _, err := tx.Exec(comando); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DividirComandos separa um script nos comandos terminados em ';'. Um ';'
+// dentro de strings, identificadores entre crases ou comentários não encerra
+// o comando. Não há suporte a DELIMITER, então corpos de trigger ou
+// procedure com vários comandos não podem ser escritos em uma migração.
+func DividirComandos(script string) []string {
+	var (
+		comandos []string
+		inicio   int
+	)
+	adicionar := func(comando string) {
+		if strings.TrimSpace(comando) != "" {
+			comandos = append(comandos, comando)
+		}
+	}
+
+	for i := 0; i < len(script); i++ {
+		switch c := script[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			i = fimDoTexto(script, i)
+		case c == '#' || comecaComentarioDeLinha(script[i:]):
+			if fim := strings.IndexByte(script[i:], '\n'); fim >= 0 {
+				i += fim
+			} else {
+				i = len(script)
+			}
+		case strings.HasPrefix(script[i:], "/*"):
+			if fim := strings.Index(script[i+2:], "*/"); fim >= 0 {
+				i += fim + 3
+			} else {
+				i = len(script)
+			}
+		case c == ';':
+			adicionar(script[inicio:i])
+			inicio = i + 1
+		}
+	}
+	if inicio < len(script) {
+		adicionar(script[inicio:])
+	}
+	return comandos
+}
+
+// fimDoTexto devolve a posição da aspa que fecha o texto aberto em inicio,
+// considerando aspas dobradas e, fora de identificadores, barras invertidas.
+func fimDoTexto(script string, inicio int) int {
+	aspa := script[inicio]
+	for i := inicio + 1; i < len(script); i++ {
+		switch script[i] {
+		case '\\':
+			if aspa != '`' {
+				i++
+			}
+		case aspa:
+			if i+1 < len(script) && script[i+1] == aspa {
+				i++
+				continue
+			}
+			return i
+		}
+	}
+	return len(script)
+}
+
+// comecaComentarioDeLinha segue o MySQL, que só trata "--" como comentário
+// quando vem seguido de espaço ou de um caractere de controle.
+func comecaComentarioDeLinha(resto string) bool {
+	return strings.HasPrefix(resto, "--") && (len(resto) == 2 || resto[2] <= ' ')
+}
@@ -0,0 +1,6 @@
+CREATE TABLE balcoes (
+    id               BIGINT       NOT NULL AUTO_INCREMENT,
+    nome_atendente   VARCHAR(255) NOT NULL,
+    fila_atendimento INT          NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,21 @@
+CREATE TABLE chamados (
+    id              BIGINT       NOT NULL AUTO_INCREMENT,
+    customer_id     BIGINT       NOT NULL,
+    data_creation   DATETIME     NOT NULL,
+    data_resolution DATETIME     NULL,
+    device_id       VARCHAR(100) NOT NULL DEFAULT '',
+    serial_number   VARCHAR(100) NOT NULL,
+    chamado         TEXT         NOT NULL,
+    status_chamado  VARCHAR(20)  NOT NULL,
+    id_balcao       BIGINT       NULL,
+    motivo          TEXT         NOT NULL,
+    produto         VARCHAR(255) NOT NULL DEFAULT '',
+    user_client     VARCHAR(255) NOT NULL DEFAULT '',
+    user_atendente  VARCHAR(255) NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    KEY idx_chamados_serial (serial_number),
+    KEY idx_chamados_customer (customer_id),
+    KEY idx_chamados_atendente_status (user_atendente, status_chamado),
+    KEY idx_chamados_balcao_status (id_balcao, status_chamado),
+    CONSTRAINT fk_chamados_balcao FOREIGN KEY (id_balcao) REFERENCES balcoes (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
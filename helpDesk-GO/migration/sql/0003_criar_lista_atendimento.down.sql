@@ -0,0 +1 @@
+DROP TABLE lista_atendimento;
@@ -0,0 +1,11 @@
+CREATE TABLE lista_atendimento (
+    id             BIGINT      NOT NULL AUTO_INCREMENT,
+    chamado_id     BIGINT      NOT NULL,
+    balcao_id      BIGINT      NOT NULL,
+    chamado_estado VARCHAR(20) NOT NULL,
+    PRIMARY KEY (id),
+    KEY idx_lista_atendimento_balcao_estado (balcao_id, chamado_estado),
+    KEY idx_lista_atendimento_chamado (chamado_id),
+    CONSTRAINT fk_lista_atendimento_chamado FOREIGN KEY (chamado_id) REFERENCES chamados (id),
+    CONSTRAINT fk_lista_atendimento_balcao FOREIGN KEY (balcao_id) REFERENCES balcoes (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
@@ -0,0 +1,2 @@
+ALTER TABLE chamados
+    ADD COLUMN user_ultima_acao VARCHAR(255) NOT NULL DEFAULT '' AFTER user_atendente;
@@ -0,0 +1,2 @@
+ALTER TABLE balcoes
+    DROP COLUMN capacidade;
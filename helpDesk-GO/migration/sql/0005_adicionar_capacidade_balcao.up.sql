@@ -0,0 +1,2 @@
+ALTER TABLE balcoes
+    ADD COLUMN capacidade INT NOT NULL DEFAULT 5 AFTER fila_atendimento;
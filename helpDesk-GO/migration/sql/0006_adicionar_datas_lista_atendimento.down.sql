@@ -0,0 +1,5 @@
+ALTER TABLE lista_atendimento
+    DROP KEY idx_lista_atendimento_chamado_aberto,
+    DROP COLUMN finished_at,
+    DROP COLUMN started_at,
+    DROP COLUMN enqueued_at;
@@ -0,0 +1,5 @@
+ALTER TABLE lista_atendimento
+    ADD COLUMN enqueued_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP AFTER chamado_estado,
+    ADD COLUMN started_at  DATETIME NULL AFTER enqueued_at,
+    ADD COLUMN finished_at DATETIME NULL AFTER started_at,
+    ADD KEY idx_lista_atendimento_chamado_aberto (chamado_id, finished_at);
@@ -0,0 +1,5 @@
+ALTER TABLE chamados
+    DROP COLUMN versao;
+
+ALTER TABLE balcoes
+    DROP COLUMN versao;
@@ -0,0 +1,5 @@
+ALTER TABLE balcoes
+    ADD COLUMN versao BIGINT NOT NULL DEFAULT 1 AFTER capacidade;
+
+ALTER TABLE chamados
+    ADD COLUMN versao BIGINT NOT NULL DEFAULT 1 AFTER user_ultima_acao;
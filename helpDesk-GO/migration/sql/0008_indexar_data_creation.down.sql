@@ -0,0 +1,2 @@
+ALTER TABLE chamados
+    DROP KEY idx_chamados_data_creation;
@@ -0,0 +1,2 @@
+ALTER TABLE chamados
+    ADD KEY idx_chamados_data_creation (data_creation, id);
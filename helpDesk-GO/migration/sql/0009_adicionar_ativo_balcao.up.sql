@@ -0,0 +1,2 @@
+ALTER TABLE balcoes
+    ADD COLUMN ativo BOOLEAN NOT NULL DEFAULT TRUE AFTER capacidade;
@@ -0,0 +1,15 @@
+UPDATE chamados c
+    JOIN atendentes a ON a.id = c.id_atendente
+SET c.user_atendente = a.nome;
+
+ALTER TABLE chamados
+    DROP FOREIGN KEY fk_chamados_atendente,
+    DROP KEY idx_chamados_id_atendente_status,
+    DROP COLUMN id_atendente;
+
+ALTER TABLE balcoes
+    DROP FOREIGN KEY fk_balcoes_atendente,
+    DROP KEY uk_balcoes_atendente,
+    DROP COLUMN id_atendente;
+
+DROP TABLE atendentes;
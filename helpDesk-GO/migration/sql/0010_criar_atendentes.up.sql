@@ -0,0 +1,74 @@
+CREATE TABLE atendentes (
+    id          BIGINT       NOT NULL AUTO_INCREMENT,
+    login       VARCHAR(255) NOT NULL,
+    nome        VARCHAR(255) NOT NULL,
+    ativo       BOOLEAN      NOT NULL DEFAULT TRUE,
+    habilidades TEXT         NOT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_atendentes_login (login)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+-- Um atendente por balcão e um por nome de atendente que só aparece em chamados.
+CREATE TABLE atendentes_origem (
+    id        BIGINT       NOT NULL AUTO_INCREMENT,
+    nome      VARCHAR(255) NOT NULL,
+    balcao_id BIGINT       NULL,
+    sufixo    VARCHAR(21)  NOT NULL,
+    login     VARCHAR(64)  NULL,
+    PRIMARY KEY (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO atendentes_origem (nome, balcao_id, sufixo)
+SELECT nome_atendente, id, CAST(id AS CHAR)
+FROM balcoes
+ORDER BY id;
+
+INSERT INTO atendentes_origem (nome, sufixo)
+SELECT user_atendente, CONCAT('c', MIN(id))
+FROM chamados
+WHERE user_atendente <> ''
+  AND user_atendente NOT IN (SELECT nome_atendente FROM balcoes)
+GROUP BY user_atendente
+ORDER BY MIN(id);
+
+-- O login é o nome em minúsculas com '.' no lugar do que não for letra ou dígito.
+UPDATE atendentes_origem
+SET login = TRIM(BOTH '.' FROM LEFT(LOWER(REGEXP_REPLACE(nome, '[^A-Za-z0-9]+', '.')), 40));
+
+-- Logins repetidos ou curtos demais recebem o id do balcão (ou 'c' e o id do primeiro chamado).
+-- O '-' não aparece no login gerado acima, então o sufixo não colide com outro login.
+UPDATE atendentes_origem o
+    JOIN (SELECT id, COUNT(*) OVER (PARTITION BY login) AS repeticoes FROM atendentes_origem) r ON r.id = o.id
+SET o.login = CONCAT(IF(o.login = '', 'atendente', o.login), '-', o.sufixo)
+WHERE r.repeticoes > 1
+   OR CHAR_LENGTH(o.login) < 2;
+
+INSERT INTO atendentes (login, nome, habilidades)
+SELECT login, nome, '[]'
+FROM atendentes_origem
+ORDER BY id;
+
+ALTER TABLE balcoes
+    ADD COLUMN id_atendente BIGINT NULL AFTER id,
+    ADD UNIQUE KEY uk_balcoes_atendente (id_atendente),
+    ADD CONSTRAINT fk_balcoes_atendente FOREIGN KEY (id_atendente) REFERENCES atendentes (id);
+
+UPDATE balcoes b
+    JOIN atendentes_origem o ON o.balcao_id = b.id
+    JOIN atendentes a ON a.login = o.login
+SET b.id_atendente = a.id;
+
+ALTER TABLE chamados
+    ADD COLUMN id_atendente BIGINT NULL AFTER user_client,
+    ADD KEY idx_chamados_id_atendente_status (id_atendente, status_chamado),
+    ADD CONSTRAINT fk_chamados_atendente FOREIGN KEY (id_atendente) REFERENCES atendentes (id);
+
+-- Um nome usado em mais de um balcão fica com o atendente do primeiro deles.
+UPDATE chamados c
+    JOIN (SELECT nome, MIN(id) AS id FROM atendentes_origem GROUP BY nome) primeiro ON primeiro.nome = c.user_atendente
+    JOIN atendentes_origem o ON o.id = primeiro.id
+    JOIN atendentes a ON a.login = o.login
+SET c.id_atendente   = a.id,
+    c.user_atendente = a.login;
+
+DROP TABLE atendentes_origem;
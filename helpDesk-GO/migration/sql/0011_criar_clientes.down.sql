@@ -0,0 +1,4 @@
+ALTER TABLE chamados
+    DROP FOREIGN KEY fk_chamados_cliente;
+
+DROP TABLE clientes;
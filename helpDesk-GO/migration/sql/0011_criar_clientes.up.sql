@@ -0,0 +1,17 @@
+CREATE TABLE clientes (
+    id        BIGINT       NOT NULL AUTO_INCREMENT,
+    nome      VARCHAR(255) NOT NULL,
+    documento VARCHAR(14)  NULL,
+    email     VARCHAR(255) NOT NULL DEFAULT '',
+    telefone  VARCHAR(20)  NOT NULL DEFAULT '',
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_clientes_documento (documento)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO clientes (id, nome)
+SELECT customer_id, COALESCE(NULLIF(MAX(user_client), ''), CONCAT('Cliente ', customer_id))
+FROM chamados
+GROUP BY customer_id;
+
+ALTER TABLE chamados
+    ADD CONSTRAINT fk_chamados_cliente FOREIGN KEY (customer_id) REFERENCES clientes (id);
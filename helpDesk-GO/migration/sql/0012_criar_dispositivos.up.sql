@@ -0,0 +1,16 @@
+CREATE TABLE dispositivos (
+    id            BIGINT       NOT NULL AUTO_INCREMENT,
+    serial_number VARCHAR(100) NOT NULL,
+    produto       VARCHAR(255) NOT NULL DEFAULT '',
+    customer_id   BIGINT       NOT NULL,
+    data_compra   DATE         NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_dispositivos_serial (serial_number),
+    KEY idx_dispositivos_customer (customer_id),
+    CONSTRAINT fk_dispositivos_cliente FOREIGN KEY (customer_id) REFERENCES clientes (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+INSERT INTO dispositivos (serial_number, produto, customer_id)
+SELECT c.serial_number, c.produto, c.customer_id
+FROM chamados c
+    JOIN (SELECT MAX(id) AS id FROM chamados GROUP BY serial_number) ultimo ON ultimo.id = c.id;
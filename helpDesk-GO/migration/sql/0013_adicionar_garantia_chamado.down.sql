@@ -0,0 +1,2 @@
+ALTER TABLE chamados
+    DROP COLUMN garantia;
@@ -0,0 +1,2 @@
+ALTER TABLE chamados
+    ADD COLUMN garantia VARCHAR(20) NOT NULL DEFAULT 'DESCONHECIDA' AFTER produto;
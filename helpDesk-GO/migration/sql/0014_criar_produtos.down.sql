@@ -0,0 +1,5 @@
+ALTER TABLE chamados
+    DROP FOREIGN KEY fk_chamados_produto,
+    DROP COLUMN id_produto;
+
+DROP TABLE produtos;
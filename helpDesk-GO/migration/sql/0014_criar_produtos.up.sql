@@ -0,0 +1,16 @@
+CREATE TABLE produtos (
+    id               BIGINT       NOT NULL AUTO_INCREMENT,
+    sku              VARCHAR(64)  NOT NULL,
+    nome             VARCHAR(255) NOT NULL,
+    categoria        VARCHAR(100) NOT NULL,
+    id_balcao_padrao BIGINT       NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY uk_produtos_sku (sku),
+    KEY idx_produtos_categoria (categoria),
+    CONSTRAINT fk_produtos_balcao FOREIGN KEY (id_balcao_padrao) REFERENCES balcoes (id)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4;
+
+ALTER TABLE chamados
+    ADD COLUMN id_produto BIGINT NULL AFTER motivo,
+    ADD KEY idx_chamados_id_produto (id_produto),
+    ADD CONSTRAINT fk_chamados_produto FOREIGN KEY (id_produto) REFERENCES produtos (id);
@@ -0,0 +1,101 @@
+package model
+
+import "time"
+
+// CampoOrdenacao é um campo pelo qual a listagem de chamados pode ser
+// ordenada. Os valores são os nomes dos campos no JSON.
+type CampoOrdenacao string
+
+const (
+	OrdenarPorID            CampoOrdenacao = "id"
+	OrdenarPorDataCreation  CampoOrdenacao = "data_creation"
+	OrdenarPorStatusChamado CampoOrdenacao = "status_chamado"
+	OrdenarPorBalcao        CampoOrdenacao = "id_balcao"
+	OrdenarPorAtendente     CampoOrdenacao = "user_atendente"
+	OrdenarPorCustomerID    CampoOrdenacao = "customer_id"
+	OrdenarPorProduto       CampoOrdenacao = "produto"
+	OrdenarPorSerialNumber  CampoOrdenacao = "serial_number"
+)
+
+func (c CampoOrdenacao) Valido() bool {
+	switch c {
+	case OrdenarPorID, OrdenarPorDataCreation, OrdenarPorStatusChamado, OrdenarPorBalcao,
+		OrdenarPorAtendente, OrdenarPorCustomerID, OrdenarPorProduto, OrdenarPorSerialNumber:
+		return true
+	}
+	return false
+}
+
+// Valor devolve o valor do campo no chamado: int64, string ou time.Time.
+func (c CampoOrdenacao) Valor(chamado Chamado) any {
+	switch c {
+	case OrdenarPorDataCreation:
+		return chamado.DataCreation
+	case OrdenarPorStatusChamado:
+		return string(chamado.StatusChamado)
+	case OrdenarPorBalcao:
+		return chamado.IDBalcao
+	case OrdenarPorAtendente:
+		return chamado.UserAtendente
+	case OrdenarPorCustomerID:
+		return chamado.CustomerID
+	case OrdenarPorProduto:
+		return chamado.Produto
+	case OrdenarPorSerialNumber:
+		return chamado.SerialNumber
+	default:
+		return chamado.ID
+	}
+}
+
+// FiltroChamados restringe a listagem; campos com valor zero não filtram.
+// CriadoAte é exclusivo.
+type FiltroChamados struct {
+	Status        []StatusChamado
+	IDBalcao      int64
+	IDAtendente   int64
+	UserAtendente string
+	CustomerID    int64
+	IDProduto     int64
+	Categoria     string
+	Produto       string
+	SerialNumber  string
+	CriadoDe      time.Time
+	CriadoAte     time.Time
+}
+
+// OrdemChamados define a ordenação. O ID sempre desempata, no mesmo sentido
+// do campo, para que a ordem seja total.
+type OrdemChamados struct {
+	Campo       CampoOrdenacao
+	Decrescente bool
+}
+
+func (o OrdemChamados) Invertida() OrdemChamados {
+	return OrdemChamados{Campo: o.Campo, Decrescente: !o.Decrescente}
+}
+
+// PosicaoChamado marca um chamado na ordenação: o valor do campo ordenado e
+// o ID usado no desempate.
+type PosicaoChamado struct {
+	Valor any
+	ID    int64
+}
+
+// ConsultaChamados descreve uma busca paginada. Com Apos preenchido a busca
+// começa logo depois dessa posição (paginação por cursor); senão pula
+// Offset chamados.
+type ConsultaChamados struct {
+	Filtro FiltroChamados
+	Ordem  OrdemChamados
+	Apos   *PosicaoChamado
+	Limite int
+	Offset int
+}
+
+// TotalCategoria conta os chamados de uma categoria de produto. Chamados sem
+// produto do catálogo ficam na categoria vazia.
+type TotalCategoria struct {
+	Categoria string `json:"categoria"`
+	Total     int64  `json:"total"`
+}
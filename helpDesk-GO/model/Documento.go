@@ -0,0 +1,56 @@
+package model
+
+import "strings"
+
+// NormalizarDocumento remove a pontuação e os espaços de um CPF ou CNPJ. O
+// documento é gravado só com os dígitos.
+func NormalizarDocumento(documento string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(".-/ ", r) {
+			return -1
+		}
+		return r
+	}, documento)
+}
+
+// DocumentoValido aceita CPF (11 dígitos) ou CNPJ (14 dígitos), com ou sem
+// pontuação, conferindo os dígitos verificadores.
+func DocumentoValido(documento string) bool {
+	digitos := NormalizarDocumento(documento)
+	if strings.Trim(digitos, "0123456789") != "" {
+		return false
+	}
+	// Sequências de um só dígito passam no cálculo, mas não são emitidas.
+	if digitos == "" || strings.Count(digitos, digitos[:1]) == len(digitos) {
+		return false
+	}
+	switch len(digitos) {
+	case 11:
+		return digitosConferem(digitos, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2})
+	case 14:
+		return digitosConferem(digitos, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2})
+	}
+	return false
+}
+
+// digitosConferem calcula os dois dígitos verificadores (módulo 11) com os
+// pesos de cada um e os compara com os dois últimos dígitos.
+func digitosConferem(digitos string, pesos ...[]int) bool {
+	base := len(digitos) - len(pesos)
+	for i, peso := range pesos {
+		soma := 0
+		for j, p := range peso {
+			soma += int(digitos[j]-'0') * p
+		}
+		verificador := soma % 11
+		if verificador < 2 {
+			verificador = 0
+		} else {
+			verificador = 11 - verificador
+		}
+		if int(digitos[base+i]-'0') != verificador {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+package model
+
+// StatusGarantia é a situação da garantia do aparelho no momento em que o
+// chamado foi aberto.
+type StatusGarantia string
+
+const (
+	GarantiaDesconhecida StatusGarantia = "DESCONHECIDA"
+	EmGarantia           StatusGarantia = "EM_GARANTIA"
+	ForaDaGarantia       StatusGarantia = "FORA_DA_GARANTIA"
+)
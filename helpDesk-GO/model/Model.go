@@ -1,37 +1,95 @@
 package model
 
 import (
-	"helpdesk/entity"
 	"time"
 )
 
 type Chamado struct {
-	ID             int64     `json:"id"`
-	CustomerID     int64     `json:"customer_id"`
-	DataCreation   time.Time `json:"data_creation"`
-	DataResolution time.Time `json:"data_resolution"`
-	DeviceID       string    `json:"device_id"`
-	SerialNumber   string    `json:"serial_number"`
-	Chamado        string    `json:"chamado"`
-	StatusChamado  string    `json:"status_chamado"`
-	IDBalcao       int64     `json:"id_balcao"`
-	Motivo         string    `json:"motivo"`
-	Produto        string    `json:"produto"`
-	UserClient     string    `json:"user_client"`
-	UserAtendente  string    `json:"user_atendente"`
-	Balcao         *Balcao   `json:"balcao"`
+	ID             int64          `json:"id"`
+	CustomerID     int64          `json:"customer_id"`
+	DataCreation   time.Time      `json:"data_creation"`
+	DataResolution time.Time      `json:"data_resolution"`
+	DeviceID       string         `json:"device_id"`
+	SerialNumber   string         `json:"serial_number"`
+	Chamado        string         `json:"chamado"`
+	StatusChamado  StatusChamado  `json:"status_chamado"`
+	IDBalcao       int64          `json:"id_balcao"`
+	Motivo         string         `json:"motivo"`
+	IDProduto      int64          `json:"id_produto"`
+	Produto        string         `json:"produto"`
+	Garantia       StatusGarantia `json:"garantia"`
+	UserClient     string         `json:"user_client"`
+	IDAtendente    int64          `json:"id_atendente"`
+	UserAtendente  string         `json:"user_atendente"`
+	UserUltimaAcao string         `json:"user_ultima_acao"`
+	PosicaoFila    int            `json:"posicao_fila,omitempty"`
+	Versao         int64          `json:"versao"`
+	Balcao         *Balcao        `json:"balcao"`
 }
 
+// Balcao.NomeAtendente repete, para exibição, o nome do atendente de
+// IDAtendente. FilaAtendimento conta os atendimentos em andamento no balcão e
+// é mantido pelo serviço de chamados; Capacidade é o máximo de atendimentos
+// simultâneos que o balcão aceita. Versao muda a cada Save, mas não quando a
+// fila anda. Balcões inativos não recebem novos chamados.
 type Balcao struct {
+	IDAtendente     int64  `json:"id_atendente"`
 	NomeAtendente   string `json:"nome_atendente"`
 	FilaAtendimento int    `json:"fila_atendimento"`
+	Capacidade      int    `json:"capacidade"`
+	Ativo           bool   `json:"ativo"`
 	ID              int64  `json:"id"`
+	Versao          int64  `json:"versao"`
 }
 
-func ConvertBalcaoEntityToBalcao(balcaoEntity *entity.BalcaoEntity) Balcao {
-	return Balcao{
-		ID:              balcaoEntity.ID,
-		NomeAtendente:   balcaoEntity.NomeAtendente,
-		FilaAtendimento: balcaoEntity.FilaAtendimento,
-	}
+// Atendente é quem opera um balcão e assume chamados. Login é único;
+// atendentes inativos não podem receber balcões nem assumir chamados.
+type Atendente struct {
+	ID          int64    `json:"id"`
+	Login       string   `json:"login"`
+	Nome        string   `json:"nome"`
+	Ativo       bool     `json:"ativo"`
+	Habilidades []string `json:"habilidades"`
+}
+
+// Cliente é o dono dos chamados abertos com seu ID em Chamado.CustomerID.
+// Documento é o CPF ou CNPJ só com os dígitos; clientes anteriores ao
+// cadastro podem estar sem documento.
+type Cliente struct {
+	ID        int64  `json:"id"`
+	Nome      string `json:"nome"`
+	Documento string `json:"documento"`
+	Email     string `json:"email"`
+	Telefone  string `json:"telefone"`
+}
+
+// Dispositivo é o aparelho atendido pelos chamados com o mesmo
+// SerialNumber. CustomerID é o dono atual e DataCompra fica zerada quando
+// não é conhecida.
+type Dispositivo struct {
+	ID           int64     `json:"id"`
+	SerialNumber string    `json:"serial_number"`
+	Produto      string    `json:"produto"`
+	CustomerID   int64     `json:"customer_id"`
+	DataCompra   time.Time `json:"data_compra"`
+}
+
+// Produto é um item do catálogo. Chamados do produto abertos sem balcão vão
+// para IDBalcaoPadrao; zero indica que o produto não tem balcão padrão.
+type Produto struct {
+	ID             int64  `json:"id"`
+	SKU            string `json:"sku"`
+	Nome           string `json:"nome"`
+	Categoria      string `json:"categoria"`
+	IDBalcaoPadrao int64  `json:"id_balcao_padrao"`
+}
+
+// OcupacaoBalcao resume a fila de um balcão no momento da consulta.
+// Capacidade já considera o padrão configurado para balcões sem limite
+// próprio.
+type OcupacaoBalcao struct {
+	EmAtendimento int
+	Capacidade    int
+	VagasLivres   int
+	Aguardando    int64
 }
@@ -0,0 +1,45 @@
+package model
+
+type StatusChamado string
+
+const (
+	Aguardando  StatusChamado = "AGUARDANDO"
+	Aberto      StatusChamado = "ABERTO"
+	EmAndamento StatusChamado = "EM_ANDAMENTO"
+	Resolvido   StatusChamado = "RESOLVIDO"
+	Fechado     StatusChamado = "FECHADO"
+)
+
+var transicoesChamado = map[StatusChamado][]StatusChamado{
+	Aguardando:  {Aberto},
+	Aberto:      {EmAndamento},
+	EmAndamento: {Resolvido},
+	Resolvido:   {Fechado, Aberto},
+	Fechado:     {},
+}
+
+func (s StatusChamado) Valido() bool {
+	_, ok := transicoesChamado[s]
+	return ok
+}
+
+// Ativo indica se o chamado ainda ocupa o aparelho, ou seja, não foi
+// resolvido nem fechado.
+func (s StatusChamado) Ativo() bool {
+	return s == Aguardando || s.OcupaVaga()
+}
+
+// OcupaVaga indica se o chamado conta para a capacidade do balcão. Chamados
+// aguardando na fila de espera ainda não ocupam vaga.
+func (s StatusChamado) OcupaVaga() bool {
+	return s == Aberto || s == EmAndamento
+}
+
+func (s StatusChamado) PodeTransicionarPara(destino StatusChamado) bool {
+	for _, permitido := range transicoesChamado[s] {
+		if permitido == destino {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"helpdesk/entity"
+)
+
+type AtendenteRepository interface {
+	FindAll() ([]entity.AtendenteEntity, error)
+	FindById(id int64) (*entity.AtendenteEntity, error)
+	FindByLogin(login string) (*entity.AtendenteEntity, error)
+	Save(atendente entity.AtendenteEntity) (entity.AtendenteEntity, error)
+}
+
+type AtendenteRepositoryImpl struct {
+	db executor
+}
+
+func NewAtendenteRepository(db *sql.DB) *AtendenteRepositoryImpl {
+	return &AtendenteRepositoryImpl{db: db}
+}
+
+const atendenteSelect = "SELECT a.id, a.login, a.nome, a.ativo, a.habilidades FROM atendentes a"
+
+func scanAtendente(row rowScanner) (entity.AtendenteEntity, error) {
+	var (
+		atendente   entity.AtendenteEntity
+		habilidades string
+	)
+	if err := row.Scan(&atendente.ID, &atendente.Login, &atendente.Nome, &atendente.Ativo, &habilidades); err != nil {
+		return entity.AtendenteEntity{}, err
+	}
+	if err := json.Unmarshal([]byte(habilidades), &atendente.Habilidades); err != nil {
+		return entity.AtendenteEntity{}, err
+	}
+	return atendente, nil
+}
+
+func (repo *AtendenteRepositoryImpl) FindAll() ([]entity.AtendenteEntity, error) {
+	rows, err := repo.db.Query(atendenteSelect + " ORDER BY a.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var atendentes []entity.AtendenteEntity
+	for rows.Next() {
+		atendente, err := scanAtendente(rows)
+		if err != nil {
+			return nil, err
+		}
+		atendentes = append(atendentes, atendente)
+	}
+	return atendentes, rows.Err()
+}
+
+func (repo *AtendenteRepositoryImpl) FindById(id int64) (*entity.AtendenteEntity, error) {
+	return repo.queryAtendente(atendenteSelect+" WHERE a.id = ?", id)
+}
+
+func (repo *AtendenteRepositoryImpl) FindByLogin(login string) (*entity.AtendenteEntity, error) {
+	return repo.queryAtendente(atendenteSelect+" WHERE a.login = ?", login)
+}
+
+// Save grava as habilidades como uma lista JSON, vazia em vez de null. Um
+// login já usado por outro atendente resulta em ErrRegistroDuplicado.
+func (repo *AtendenteRepositoryImpl) Save(atendente entity.AtendenteEntity) (entity.AtendenteEntity, error) {
+	habilidades, err := json.Marshal(habilidadesOuVazio(atendente.Habilidades))
+	if err != nil {
+		return entity.AtendenteEntity{}, err
+	}
+
+	if atendente.ID != 0 {
+		query := "UPDATE atendentes SET login = ?, nome = ?, ativo = ?, habilidades = ? WHERE id = ?"
+		if _, err := repo.db.Exec(query, atendente.Login, atendente.Nome, atendente.Ativo, string(habilidades), atendente.ID); err != nil {
+			return entity.AtendenteEntity{}, traduzirDuplicado(err)
+		}
+		return atendente, nil
+	}
+
+	query := "INSERT INTO atendentes (login, nome, ativo, habilidades) VALUES (?, ?, ?, ?)"
+	result, err := repo.db.Exec(query, atendente.Login, atendente.Nome, atendente.Ativo, string(habilidades))
+	if err != nil {
+		return entity.AtendenteEntity{}, traduzirDuplicado(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.AtendenteEntity{}, err
+	}
+	atendente.ID = id
+	return atendente, nil
+}
+
+func (repo *AtendenteRepositoryImpl) queryAtendente(query string, args ...any) (*entity.AtendenteEntity, error) {
+	atendente, err := scanAtendente(repo.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &atendente, nil
+}
+
+func habilidadesOuVazio(habilidades []string) []string {
+	if habilidades == nil {
+		return []string{}
+	}
+	return habilidades
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"cmp"
+	"helpdesk/entity"
+	"slices"
+	"strings"
+)
+
+type AtendenteRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewAtendenteRepositoryMemoria(banco *BancoMemoria) *AtendenteRepositoryMemoria {
+	return &AtendenteRepositoryMemoria{banco: banco}
+}
+
+func (repo *AtendenteRepositoryMemoria) FindAll() ([]entity.AtendenteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var atendentes []entity.AtendenteEntity
+	for _, atendente := range repo.banco.atendentes {
+		atendentes = append(atendentes, atendente)
+	}
+	slices.SortFunc(atendentes, func(a, b entity.AtendenteEntity) int { return cmp.Compare(a.ID, b.ID) })
+	return atendentes, nil
+}
+
+func (repo *AtendenteRepositoryMemoria) FindById(id int64) (*entity.AtendenteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	atendente, ok := repo.banco.atendentes[id]
+	if !ok {
+		return nil, nil
+	}
+	return &atendente, nil
+}
+
+// FindByLogin ignora maiúsculas e minúsculas, como a collation do MySQL.
+func (repo *AtendenteRepositoryMemoria) FindByLogin(login string) (*entity.AtendenteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for _, atendente := range repo.banco.atendentes {
+		if strings.EqualFold(atendente.Login, login) {
+			return &atendente, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *AtendenteRepositoryMemoria) Save(atendente entity.AtendenteEntity) (entity.AtendenteEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	for _, existente := range repo.banco.atendentes {
+		if existente.ID != atendente.ID && strings.EqualFold(existente.Login, atendente.Login) {
+			return entity.AtendenteEntity{}, ErrRegistroDuplicado
+		}
+	}
+	atendente.ID = proximoID(&repo.banco.ultimoAtendenteID, atendente.ID)
+	atendente.Habilidades = slices.Clone(habilidadesOuVazio(atendente.Habilidades))
+	repo.banco.atendentes[atendente.ID] = atendente
+	return atendente, nil
+}
@@ -4,38 +4,88 @@ import (
 	"database/sql"
 	"fmt"
 	"helpdesk/entity"
+	"helpdesk/model"
 )
 
 type AtendimentoRepository interface {
 	Save(atendimento *entity.ListaAtendimento) error
+	FindAbertoByChamado(chamadoId int64) (*entity.ListaAtendimento, error)
 	FindOpenByBalcao(balcaoId int64) (int64, error)
 }
+
 type ListaAtendimentoRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
-type AtendimentoService struct {
-	atendimentoRepository AtendimentoRepository
-	ListaAtendimento      *entity.ListaAtendimento
+func NovoListaAtendimentoRepository(db *sql.DB) *ListaAtendimentoRepositoryImpl {
+	return &ListaAtendimentoRepositoryImpl{db: db}
 }
 
-func NovoAtendimentoService(repo AtendimentoRepository) *AtendimentoService {
-	return &AtendimentoService{
-		atendimentoRepository: repo,
+func (repo *ListaAtendimentoRepositoryImpl) Save(atendimento *entity.ListaAtendimento) error {
+	if atendimento.Chamado == nil || atendimento.Balcao == nil {
+		return fmt.Errorf("atendimento precisa de chamado e balcão")
+	}
+
+	query := `INSERT INTO lista_atendimento (id, chamado_id, balcao_id, chamado_estado, enqueued_at, started_at, finished_at)
+	          VALUES (NULLIF(?, 0), ?, ?, ?, ?, ?, ?)
+	          ON DUPLICATE KEY UPDATE
+	              id = LAST_INSERT_ID(id),
+	              chamado_estado = VALUES(chamado_estado),
+	              started_at = VALUES(started_at),
+	              finished_at = VALUES(finished_at)`
+
+	result, err := repo.db.Exec(query,
+		atendimento.ID, atendimento.Chamado.ID, atendimento.Balcao.ID, atendimento.Chamado.StatusChamado,
+		atendimento.DataEntrada, nullTime(atendimento.DataInicio), nullTime(atendimento.DataFim),
+	)
+	if err != nil {
+		return fmt.Errorf("erro ao salvar atendimento: %w", err)
 	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erro ao salvar atendimento: %w", err)
+	}
+	atendimento.ID = id
+	return nil
 }
 
-func NovoListaAtendimentoRepository(db *sql.DB) *ListaAtendimentoRepositoryImpl {
-	return &ListaAtendimentoRepositoryImpl{db: db}
+// FindAbertoByChamado devolve o atendimento do chamado que ainda ocupa vaga,
+// ou nil se não houver. Chamado e Balcao vêm preenchidos apenas com o ID.
+func (repo *ListaAtendimentoRepositoryImpl) FindAbertoByChamado(chamadoId int64) (*entity.ListaAtendimento, error) {
+	query := `SELECT id, chamado_id, balcao_id, chamado_estado, enqueued_at, started_at
+	          FROM lista_atendimento
+	          WHERE chamado_id = ? AND finished_at IS NULL
+	          ORDER BY id DESC
+	          LIMIT 1`
+
+	var (
+		atendimento = entity.ListaAtendimento{Chamado: &entity.ChamadoEntity{}, Balcao: &entity.BalcaoEntity{}}
+		dataInicio  sql.NullTime
+	)
+	err := repo.db.QueryRow(query, chamadoId).Scan(
+		&atendimento.ID, &atendimento.Chamado.ID, &atendimento.Balcao.ID, &atendimento.Chamado.StatusChamado,
+		&atendimento.DataEntrada, &dataInicio,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("erro ao buscar atendimento do chamado %d: %w", chamadoId, err)
+	}
+	atendimento.Chamado.IDBalcao = atendimento.Balcao.ID
+	atendimento.DataInicio = dataInicio.Time
+	return &atendimento, nil
 }
 
-func (repo *ListaAtendimentoRepositoryImpl) FindOpenByBalcao(balcaoID int64) (int, error) {
-	var count int
-	query := `SELECT COUNT(*) 
-	          FROM lista_atendimento 
-	          WHERE balcao_id = ? AND chamado_estado != ?`
+func (repo *ListaAtendimentoRepositoryImpl) FindOpenByBalcao(balcaoID int64) (int64, error) {
+	var count int64
+	query := `SELECT COUNT(DISTINCT la.chamado_id)
+	          FROM lista_atendimento la
+	          JOIN chamados c ON c.id = la.chamado_id
+	          WHERE la.balcao_id = ? AND c.status_chamado IN (?, ?)`
 
-	err := repo.db.QueryRow(query, balcaoID, "CONCLUIDO").Scan(&count)
+	err := repo.db.QueryRow(query, balcaoID, model.Aberto, model.EmAndamento).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("erro ao contar atendimentos: %w", err)
 	}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"fmt"
+	"helpdesk/entity"
+)
+
+type AtendimentoRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewAtendimentoRepositoryMemoria(banco *BancoMemoria) *AtendimentoRepositoryMemoria {
+	return &AtendimentoRepositoryMemoria{banco: banco}
+}
+
+func (repo *AtendimentoRepositoryMemoria) Save(atendimento *entity.ListaAtendimento) error {
+	if atendimento.Chamado == nil || atendimento.Balcao == nil {
+		return fmt.Errorf("atendimento precisa de chamado e balcão")
+	}
+
+	defer repo.banco.travarEscrita()()
+
+	registro := registroAtendimento{
+		chamadoID:     atendimento.Chamado.ID,
+		balcaoID:      atendimento.Balcao.ID,
+		chamadoEstado: atendimento.Chamado.StatusChamado,
+		dataEntrada:   atendimento.DataEntrada,
+		dataInicio:    atendimento.DataInicio,
+		dataFim:       atendimento.DataFim,
+	}
+	for i, existente := range repo.banco.atendimentos {
+		if atendimento.ID != 0 && existente.id == atendimento.ID {
+			registro.id = existente.id
+			registro.dataEntrada = existente.dataEntrada
+			repo.banco.atendimentos[i] = registro
+			return nil
+		}
+	}
+
+	registro.id = proximoID(&repo.banco.ultimoAtendimentoID, atendimento.ID)
+	atendimento.ID = registro.id
+	repo.banco.atendimentos = append(repo.banco.atendimentos, registro)
+	return nil
+}
+
+func (repo *AtendimentoRepositoryMemoria) FindAbertoByChamado(chamadoId int64) (*entity.ListaAtendimento, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for i := len(repo.banco.atendimentos) - 1; i >= 0; i-- {
+		registro := repo.banco.atendimentos[i]
+		if registro.chamadoID != chamadoId || !registro.dataFim.IsZero() {
+			continue
+		}
+		chamado := &entity.ChamadoEntity{}
+		chamado.ID = registro.chamadoID
+		chamado.IDBalcao = registro.balcaoID
+		chamado.StatusChamado = registro.chamadoEstado
+		balcao := &entity.BalcaoEntity{}
+		balcao.ID = registro.balcaoID
+		return &entity.ListaAtendimento{
+			ID:          registro.id,
+			Chamado:     chamado,
+			Balcao:      balcao,
+			DataEntrada: registro.dataEntrada,
+			DataInicio:  registro.dataInicio,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (repo *AtendimentoRepositoryMemoria) FindOpenByBalcao(balcaoId int64) (int64, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	abertos := map[int64]bool{}
+	for _, atendimento := range repo.banco.atendimentos {
+		if atendimento.balcaoID != balcaoId {
+			continue
+		}
+		if chamado, ok := repo.banco.chamados[atendimento.chamadoID]; ok && chamado.StatusChamado.OcupaVaga() {
+			abertos[atendimento.chamadoID] = true
+		}
+	}
+	return int64(len(abertos)), nil
+}
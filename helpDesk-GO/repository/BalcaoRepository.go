@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"helpdesk/entity"
 )
 
@@ -8,8 +9,166 @@ type BalcaoRepository interface {
 	FindAll() ([]entity.BalcaoEntity, error)
 	Save(balcao entity.BalcaoEntity) (entity.BalcaoEntity, error)
 	FindById(id int64) (*entity.BalcaoEntity, error)
+	FindByIdForUpdate(id int64) (*entity.BalcaoEntity, error)
 	FindByCustomerId(customerId int64) ([]entity.BalcaoEntity, error)
+	FindByAtendente(idAtendente int64) (*entity.BalcaoEntity, error)
+	ReservarVaga(id int64, capacidadePadrao int) (bool, error)
+	LiberarVaga(id int64) (bool, error)
 }
 
 type BalcaoRepositoryImpl struct {
+	db executor
+}
+
+func NewBalcaoRepository(db *sql.DB) *BalcaoRepositoryImpl {
+	return &BalcaoRepositoryImpl{db: db}
+}
+
+const balcaoColunas = "b.id, b.id_atendente, b.nome_atendente, b.fila_atendimento, b.capacidade, b.ativo, b.versao"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBalcao(row rowScanner) (entity.BalcaoEntity, error) {
+	var (
+		balcao      entity.BalcaoEntity
+		idAtendente sql.NullInt64
+	)
+	err := row.Scan(&balcao.ID, &idAtendente, &balcao.NomeAtendente, &balcao.FilaAtendimento, &balcao.Capacidade, &balcao.Ativo, &balcao.Versao)
+	balcao.IDAtendente = idAtendente.Int64
+	return balcao, err
+}
+
+func (repo *BalcaoRepositoryImpl) FindAll() ([]entity.BalcaoEntity, error) {
+	query := "SELECT " + balcaoColunas + " FROM balcoes b ORDER BY b.id"
+	return repo.queryBalcoes(query)
+}
+
+// Save segue a mesma regra de versão de ChamadoRepositoryImpl.Save. A fila
+// de atendimento só é gravada na inserção; depois disso ela muda apenas por
+// ReservarVaga e LiberarVaga.
+func (repo *BalcaoRepositoryImpl) Save(balcao entity.BalcaoEntity) (entity.BalcaoEntity, error) {
+	if balcao.Versao == 0 {
+		return repo.inserir(balcao)
+	}
+
+	query := `UPDATE balcoes
+	          SET id_atendente = NULLIF(?, 0), nome_atendente = ?, capacidade = ?, ativo = ?, versao = versao + 1
+	          WHERE id = ? AND versao = ?`
+
+	result, err := repo.db.Exec(query, balcao.IDAtendente, balcao.NomeAtendente, balcao.Capacidade, balcao.Ativo, balcao.ID, balcao.Versao)
+	if err != nil {
+		return entity.BalcaoEntity{}, traduzirDuplicado(err)
+	}
+
+	afetadas, err := result.RowsAffected()
+	if err != nil {
+		return entity.BalcaoEntity{}, err
+	}
+	if err := conferirVersao(afetadas); err != nil {
+		return entity.BalcaoEntity{}, err
+	}
+	balcao.Versao++
+	return balcao, nil
+}
+
+func (repo *BalcaoRepositoryImpl) inserir(balcao entity.BalcaoEntity) (entity.BalcaoEntity, error) {
+	query := `INSERT INTO balcoes (id, id_atendente, nome_atendente, fila_atendimento, capacidade, ativo, versao)
+	          VALUES (NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?, 1)`
+
+	result, err := repo.db.Exec(query, balcao.ID, balcao.IDAtendente, balcao.NomeAtendente, balcao.FilaAtendimento, balcao.Capacidade, balcao.Ativo)
+	if err != nil {
+		return entity.BalcaoEntity{}, traduzirDuplicado(err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.BalcaoEntity{}, err
+	}
+	balcao.ID = id
+	balcao.Versao = 1
+	return balcao, nil
+}
+
+// ReservarVaga ocupa uma vaga do balcão somente se ele estiver ativo e ainda
+// abaixo da capacidade. A verificação e o incremento acontecem no mesmo
+// UPDATE, então duas reservas concorrentes nunca ultrapassam o limite. Balcões
+// sem capacidade definida usam capacidadePadrao.
+func (repo *BalcaoRepositoryImpl) ReservarVaga(id int64, capacidadePadrao int) (bool, error) {
+	query := `UPDATE balcoes
+	          SET fila_atendimento = fila_atendimento + 1
+	          WHERE id = ? AND ativo AND fila_atendimento < IF(capacidade > 0, capacidade, ?)`
+	return repo.atualizarVaga(query, id, capacidadePadrao)
+}
+
+func (repo *BalcaoRepositoryImpl) LiberarVaga(id int64) (bool, error) {
+	query := `UPDATE balcoes
+	          SET fila_atendimento = fila_atendimento - 1
+	          WHERE id = ? AND fila_atendimento > 0`
+	return repo.atualizarVaga(query, id)
+}
+
+func (repo *BalcaoRepositoryImpl) atualizarVaga(query string, args ...any) (bool, error) {
+	result, err := repo.db.Exec(query, args...)
+	if err != nil {
+		return false, err
+	}
+	afetadas, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return afetadas == 1, nil
+}
+
+func (repo *BalcaoRepositoryImpl) FindById(id int64) (*entity.BalcaoEntity, error) {
+	return repo.queryBalcao("SELECT "+balcaoColunas+" FROM balcoes b WHERE b.id = ?", id)
+}
+
+// FindByIdForUpdate lê o balcão travando a linha até o fim da transação, de
+// modo que reservas de vaga concorrentes esperam por ela.
+func (repo *BalcaoRepositoryImpl) FindByIdForUpdate(id int64) (*entity.BalcaoEntity, error) {
+	return repo.queryBalcao("SELECT "+balcaoColunas+" FROM balcoes b WHERE b.id = ? FOR UPDATE", id)
+}
+
+func (repo *BalcaoRepositoryImpl) FindByAtendente(idAtendente int64) (*entity.BalcaoEntity, error) {
+	return repo.queryBalcao("SELECT "+balcaoColunas+" FROM balcoes b WHERE b.id_atendente = ?", idAtendente)
+}
+
+func (repo *BalcaoRepositoryImpl) queryBalcao(query string, args ...any) (*entity.BalcaoEntity, error) {
+	balcao, err := scanBalcao(repo.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &balcao, nil
+}
+
+func (repo *BalcaoRepositoryImpl) FindByCustomerId(customerId int64) ([]entity.BalcaoEntity, error) {
+	query := `SELECT DISTINCT ` + balcaoColunas + `
+	          FROM balcoes b
+	          JOIN chamados c ON c.id_balcao = b.id
+	          WHERE c.customer_id = ?
+	          ORDER BY b.id`
+	return repo.queryBalcoes(query, customerId)
+}
+
+func (repo *BalcaoRepositoryImpl) queryBalcoes(query string, args ...any) ([]entity.BalcaoEntity, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var balcoes []entity.BalcaoEntity
+	for rows.Next() {
+		balcao, err := scanBalcao(rows)
+		if err != nil {
+			return nil, err
+		}
+		balcoes = append(balcoes, balcao)
+	}
+	return balcoes, rows.Err()
 }
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"helpdesk/entity"
+	"sort"
+)
+
+type BalcaoRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewBalcaoRepositoryMemoria(banco *BancoMemoria) *BalcaoRepositoryMemoria {
+	return &BalcaoRepositoryMemoria{banco: banco}
+}
+
+func (repo *BalcaoRepositoryMemoria) FindAll() ([]entity.BalcaoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var balcoes []entity.BalcaoEntity
+	for _, balcao := range repo.banco.balcoes {
+		balcoes = append(balcoes, balcao)
+	}
+	ordenarBalcoes(balcoes)
+	return balcoes, nil
+}
+
+func (repo *BalcaoRepositoryMemoria) Save(balcao entity.BalcaoEntity) (entity.BalcaoEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	if balcao.Versao != 0 && repo.banco.balcoes[balcao.ID].Versao != balcao.Versao {
+		return entity.BalcaoEntity{}, ErrVersaoDesatualizada
+	}
+	if balcao.IDAtendente != 0 {
+		for _, existente := range repo.banco.balcoes {
+			if existente.ID != balcao.ID && existente.IDAtendente == balcao.IDAtendente {
+				return entity.BalcaoEntity{}, ErrRegistroDuplicado
+			}
+		}
+	}
+	balcao.ID = proximoID(&repo.banco.ultimoBalcaoID, balcao.ID)
+	balcao.Versao++
+	if existente, ok := repo.banco.balcoes[balcao.ID]; ok {
+		balcao.FilaAtendimento = existente.FilaAtendimento
+	}
+	repo.banco.balcoes[balcao.ID] = balcao
+	return balcao, nil
+}
+
+func (repo *BalcaoRepositoryMemoria) FindById(id int64) (*entity.BalcaoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	balcao, ok := repo.banco.balcoes[id]
+	if !ok {
+		return nil, nil
+	}
+	return &balcao, nil
+}
+
+// FindByIdForUpdate não precisa travar nada: as transações em memória já são
+// serializadas com as demais escritas.
+func (repo *BalcaoRepositoryMemoria) FindByIdForUpdate(id int64) (*entity.BalcaoEntity, error) {
+	return repo.FindById(id)
+}
+
+func (repo *BalcaoRepositoryMemoria) FindByAtendente(idAtendente int64) (*entity.BalcaoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for _, balcao := range repo.banco.balcoes {
+		if balcao.IDAtendente == idAtendente {
+			return &balcao, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *BalcaoRepositoryMemoria) FindByCustomerId(customerId int64) ([]entity.BalcaoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	encontrados := map[int64]bool{}
+	var balcoes []entity.BalcaoEntity
+	for _, chamado := range repo.banco.chamados {
+		if chamado.CustomerID != customerId || encontrados[chamado.IDBalcao] {
+			continue
+		}
+		if balcao, ok := repo.banco.balcoes[chamado.IDBalcao]; ok {
+			encontrados[chamado.IDBalcao] = true
+			balcoes = append(balcoes, balcao)
+		}
+	}
+	ordenarBalcoes(balcoes)
+	return balcoes, nil
+}
+
+func (repo *BalcaoRepositoryMemoria) ReservarVaga(id int64, capacidadePadrao int) (bool, error) {
+	defer repo.banco.travarEscrita()()
+
+	balcao, ok := repo.banco.balcoes[id]
+	if !ok || !balcao.Ativo {
+		return false, nil
+	}
+	capacidade := balcao.Capacidade
+	if capacidade <= 0 {
+		capacidade = capacidadePadrao
+	}
+	if balcao.FilaAtendimento >= capacidade {
+		return false, nil
+	}
+	balcao.FilaAtendimento++
+	repo.banco.balcoes[id] = balcao
+	return true, nil
+}
+
+func (repo *BalcaoRepositoryMemoria) LiberarVaga(id int64) (bool, error) {
+	defer repo.banco.travarEscrita()()
+
+	balcao, ok := repo.banco.balcoes[id]
+	if !ok || balcao.FilaAtendimento <= 0 {
+		return false, nil
+	}
+	balcao.FilaAtendimento--
+	repo.banco.balcoes[id] = balcao
+	return true, nil
+}
+
+func ordenarBalcoes(balcoes []entity.BalcaoEntity) {
+	sort.Slice(balcoes, func(i, j int) bool { return balcoes[i].ID < balcoes[j].ID })
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"helpdesk/entity"
+	"helpdesk/model"
+	"sync"
+	"time"
+)
+
+type registroAtendimento struct {
+	id            int64
+	chamadoID     int64
+	balcaoID      int64
+	chamadoEstado model.StatusChamado
+	dataEntrada   time.Time
+	dataInicio    time.Time
+	dataFim       time.Time
+}
+
+// BancoMemoria guarda as tabelas usadas pelos repositórios em memória. Os
+// repositórios criados sobre o mesmo banco enxergam os dados uns dos outros,
+// como aconteceria com as tabelas do MySQL.
+type BancoMemoria struct {
+	mu      sync.RWMutex
+	escrita sync.Mutex
+
+	balcoes      map[int64]entity.BalcaoEntity
+	chamados     map[int64]entity.ChamadoEntity
+	atendentes   map[int64]entity.AtendenteEntity
+	clientes     map[int64]entity.ClienteEntity
+	dispositivos map[int64]entity.DispositivoEntity
+	produtos     map[int64]entity.ProdutoEntity
+	atendimentos []registroAtendimento
+
+	ultimoBalcaoID      int64
+	ultimoChamadoID     int64
+	ultimoAtendenteID   int64
+	ultimoClienteID     int64
+	ultimoDispositivoID int64
+	ultimoProdutoID     int64
+	ultimoAtendimentoID int64
+}
+
+func NovoBancoMemoria() *BancoMemoria {
+	return &BancoMemoria{
+		balcoes:      map[int64]entity.BalcaoEntity{},
+		chamados:     map[int64]entity.ChamadoEntity{},
+		atendentes:   map[int64]entity.AtendenteEntity{},
+		clientes:     map[int64]entity.ClienteEntity{},
+		dispositivos: map[int64]entity.DispositivoEntity{},
+		produtos:     map[int64]entity.ProdutoEntity{},
+	}
+}
+
+func proximoID(ultimo *int64, id int64) int64 {
+	if id == 0 {
+		*ultimo++
+		return *ultimo
+	}
+	if id > *ultimo {
+		*ultimo = id
+	}
+	return id
+}
+
+// travarEscrita deve envolver toda gravação feita pelos repositórios em
+// memória, para que ela não aconteça no meio de uma transação.
+func (b *BancoMemoria) travarEscrita() func() {
+	b.escrita.Lock()
+	b.mu.Lock()
+	return func() {
+		b.mu.Unlock()
+		b.escrita.Unlock()
+	}
+}
+
+func (b *BancoMemoria) copiar() *BancoMemoria {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	copia := &BancoMemoria{
+		balcoes:             make(map[int64]entity.BalcaoEntity, len(b.balcoes)),
+		chamados:            make(map[int64]entity.ChamadoEntity, len(b.chamados)),
+		atendentes:          make(map[int64]entity.AtendenteEntity, len(b.atendentes)),
+		clientes:            make(map[int64]entity.ClienteEntity, len(b.clientes)),
+		dispositivos:        make(map[int64]entity.DispositivoEntity, len(b.dispositivos)),
+		produtos:            make(map[int64]entity.ProdutoEntity, len(b.produtos)),
+		atendimentos:        append([]registroAtendimento(nil), b.atendimentos...),
+		ultimoBalcaoID:      b.ultimoBalcaoID,
+		ultimoChamadoID:     b.ultimoChamadoID,
+		ultimoAtendenteID:   b.ultimoAtendenteID,
+		ultimoClienteID:     b.ultimoClienteID,
+		ultimoDispositivoID: b.ultimoDispositivoID,
+		ultimoProdutoID:     b.ultimoProdutoID,
+		ultimoAtendimentoID: b.ultimoAtendimentoID,
+	}
+	for id, balcao := range b.balcoes {
+		copia.balcoes[id] = balcao
+	}
+	for id, chamado := range b.chamados {
+		copia.chamados[id] = chamado
+	}
+	for id, atendente := range b.atendentes {
+		copia.atendentes[id] = atendente
+	}
+	for id, cliente := range b.clientes {
+		copia.clientes[id] = cliente
+	}
+	for id, dispositivo := range b.dispositivos {
+		copia.dispositivos[id] = dispositivo
+	}
+	for id, produto := range b.produtos {
+		copia.produtos[id] = produto
+	}
+	return copia
+}
+
+func (b *BancoMemoria) restaurar(de *BancoMemoria) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.balcoes = de.balcoes
+	b.chamados = de.chamados
+	b.atendentes = de.atendentes
+	b.clientes = de.clientes
+	b.dispositivos = de.dispositivos
+	b.produtos = de.produtos
+	b.atendimentos = de.atendimentos
+	b.ultimoBalcaoID = de.ultimoBalcaoID
+	b.ultimoChamadoID = de.ultimoChamadoID
+	b.ultimoAtendenteID = de.ultimoAtendenteID
+	b.ultimoClienteID = de.ultimoClienteID
+	b.ultimoDispositivoID = de.ultimoDispositivoID
+	b.ultimoProdutoID = de.ultimoProdutoID
+	b.ultimoAtendimentoID = de.ultimoAtendimentoID
+}
@@ -2,8 +2,10 @@ package repository
 
 import (
 	"database/sql"
-	"helpdesk/dto"
 	"helpdesk/entity"
+	"helpdesk/model"
+	"strings"
+	"time"
 )
 
 type ChamadoRepository interface {
@@ -11,53 +13,344 @@ type ChamadoRepository interface {
 	Save(chamado *entity.ChamadoEntity) (*entity.ChamadoEntity, error)
 	FindById(id int64) (*entity.ChamadoEntity, error)
 	FindByCustomerId(customerId int64) ([]entity.ChamadoEntity, error)
-	FindByUsuarioAtendenteAndEstado(usuarioAtendente string, estado entity.StatusChamado) ([]entity.ChamadoEntity, error)
-	FindByBalcaoAndStatus(balcao entity.BalcaoEntity, status dto.StatusChamado) ([]entity.ChamadoEntity, error)
+	FindByAtendenteAndEstado(idAtendente int64, estado model.StatusChamado) ([]entity.ChamadoEntity, error)
+	FindByBalcaoAndStatus(balcao entity.BalcaoEntity, status model.StatusChamado) ([]entity.ChamadoEntity, error)
 	FindBySerial(serial string) (*entity.ChamadoEntity, error)
+	FindHistoricoBySerial(serial string) ([]entity.ChamadoEntity, error)
 	FindAllPaginated(page int, size int) ([]entity.ChamadoEntity, error)
+	BuscarChamados(consulta model.ConsultaChamados) ([]entity.ChamadoEntity, error)
+	ContarChamados(filtro model.FiltroChamados) (int64, error)
+	ContarPorCategoria(filtro model.FiltroChamados) ([]model.TotalCategoria, error)
 }
 
 type ChamadoRepositoryImpl struct {
-	db *sql.DB
+	db executor
 }
 
 func NewChamadoRepository(db *sql.DB) *ChamadoRepositoryImpl {
 	return &ChamadoRepositoryImpl{db: db}
 }
 
-func (repo ChamadoRepositoryImpl) FindBySerial(serial string) (dto.ChamadoDTO, error) {
-	var chamado dto.ChamadoDTO
-	query := "SELECT id, serial_number FROM chamados WHERE serial_number = ?"
+const chamadoSelect = `SELECT c.id, c.customer_id, c.data_creation, c.data_resolution, c.device_id,
+	       c.serial_number, c.chamado, c.status_chamado, c.id_balcao, c.motivo, c.id_produto, c.produto, c.garantia,
+	       c.user_client, c.id_atendente, c.user_atendente, c.user_ultima_acao, c.versao,
+	       b.id, b.id_atendente, b.nome_atendente, b.fila_atendimento, b.capacidade, b.ativo, b.versao
+	FROM chamados c
+	LEFT JOIN balcoes b ON b.id = c.id_balcao`
 
-	row := repo.db.QueryRow(query, serial)
-	err := row.Scan(&chamado.ID, &chamado.SerialNumber)
+func scanChamado(row rowScanner) (entity.ChamadoEntity, error) {
+	var (
+		chamado         entity.ChamadoEntity
+		dataResolution  sql.NullTime
+		idBalcao        sql.NullInt64
+		idAtendente     sql.NullInt64
+		idProduto       sql.NullInt64
+		balcaoID        sql.NullInt64
+		balcaoAtendente sql.NullInt64
+		nomeAtendente   sql.NullString
+		filaAtendimento sql.NullInt64
+		capacidade      sql.NullInt64
+		ativo           sql.NullBool
+		versaoBalcao    sql.NullInt64
+	)
+
+	err := row.Scan(
+		&chamado.ID, &chamado.CustomerID, &chamado.DataCreation, &dataResolution, &chamado.DeviceID,
+		&chamado.SerialNumber, &chamado.Chamado.Chamado, &chamado.StatusChamado, &idBalcao, &chamado.Motivo, &idProduto, &chamado.Produto, &chamado.Garantia,
+		&chamado.UserClient, &idAtendente, &chamado.UserAtendente, &chamado.UserUltimaAcao, &chamado.Versao,
+		&balcaoID, &balcaoAtendente, &nomeAtendente, &filaAtendimento, &capacidade, &ativo, &versaoBalcao,
+	)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return dto.ChamadoDTO{}, nil
+		return entity.ChamadoEntity{}, err
+	}
+
+	chamado.DataResolution = dataResolution.Time
+	chamado.IDBalcao = idBalcao.Int64
+	chamado.IDAtendente = idAtendente.Int64
+	chamado.IDProduto = idProduto.Int64
+	if balcaoID.Valid {
+		chamado.Balcao = &model.Balcao{
+			ID:              balcaoID.Int64,
+			IDAtendente:     balcaoAtendente.Int64,
+			NomeAtendente:   nomeAtendente.String,
+			FilaAtendimento: int(filaAtendimento.Int64),
+			Capacidade:      int(capacidade.Int64),
+			Ativo:           ativo.Bool,
+			Versao:          versaoBalcao.Int64,
 		}
-		return dto.ChamadoDTO{}, err
 	}
 	return chamado, nil
 }
 
-func (repo *ChamadoRepositoryImpl) FindAllPaginated(page int, size int) ([]dto.ChamadoDTO, error) {
+func (repo *ChamadoRepositoryImpl) FindAll() ([]entity.ChamadoEntity, error) {
+	return repo.queryChamados(chamadoSelect + " ORDER BY c.id")
+}
+
+// Save insere chamados com versão zero e atualiza os demais somente se a
+// versão salva ainda for a do chamado, incrementando-a.
+func (repo *ChamadoRepositoryImpl) Save(chamado *entity.ChamadoEntity) (*entity.ChamadoEntity, error) {
+	if chamado.Versao == 0 {
+		return repo.inserir(chamado)
+	}
+
+	query := `UPDATE chamados
+	          SET customer_id = ?, data_creation = ?, data_resolution = ?, device_id = ?, serial_number = ?,
+	              chamado = ?, status_chamado = ?, id_balcao = NULLIF(?, 0), motivo = ?, id_produto = NULLIF(?, 0), produto = ?, garantia = ?,
+	              user_client = ?, id_atendente = NULLIF(?, 0), user_atendente = ?, user_ultima_acao = ?, versao = versao + 1
+	          WHERE id = ? AND versao = ?`
+
+	result, err := repo.db.Exec(query,
+		chamado.CustomerID, chamado.DataCreation, nullTime(chamado.DataResolution), chamado.DeviceID, chamado.SerialNumber,
+		chamado.Chamado.Chamado, chamado.StatusChamado, chamado.IDBalcao, chamado.Motivo, chamado.IDProduto, chamado.Produto, garantiaOuPadrao(chamado.Garantia),
+		chamado.UserClient, chamado.IDAtendente, chamado.UserAtendente, chamado.UserUltimaAcao,
+		chamado.ID, chamado.Versao,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	afetadas, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if err := conferirVersao(afetadas); err != nil {
+		return nil, err
+	}
+	chamado.Versao++
+	return chamado, nil
+}
+
+func (repo *ChamadoRepositoryImpl) inserir(chamado *entity.ChamadoEntity) (*entity.ChamadoEntity, error) {
+	query := `INSERT INTO chamados (id, customer_id, data_creation, data_resolution, device_id, serial_number,
+	                                chamado, status_chamado, id_balcao, motivo, id_produto, produto, garantia,
+	                                user_client, id_atendente, user_atendente, user_ultima_acao, versao)
+	          VALUES (NULLIF(?, 0), ?, ?, ?, ?, ?, ?, ?, NULLIF(?, 0), ?, NULLIF(?, 0), ?, ?, ?, NULLIF(?, 0), ?, ?, 1)`
+
+	result, err := repo.db.Exec(query,
+		chamado.ID, chamado.CustomerID, chamado.DataCreation, nullTime(chamado.DataResolution), chamado.DeviceID, chamado.SerialNumber,
+		chamado.Chamado.Chamado, chamado.StatusChamado, chamado.IDBalcao, chamado.Motivo, chamado.IDProduto, chamado.Produto, garantiaOuPadrao(chamado.Garantia),
+		chamado.UserClient, chamado.IDAtendente, chamado.UserAtendente, chamado.UserUltimaAcao,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	chamado.ID = id
+	chamado.Versao = 1
+	return chamado, nil
+}
+
+func (repo *ChamadoRepositoryImpl) FindById(id int64) (*entity.ChamadoEntity, error) {
+	return repo.queryChamado(chamadoSelect+" WHERE c.id = ?", id)
+}
+
+func (repo *ChamadoRepositoryImpl) FindByCustomerId(customerId int64) ([]entity.ChamadoEntity, error) {
+	return repo.queryChamados(chamadoSelect+" WHERE c.customer_id = ? ORDER BY c.data_creation DESC", customerId)
+}
+
+func (repo *ChamadoRepositoryImpl) FindByAtendenteAndEstado(idAtendente int64, estado model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	query := chamadoSelect + " WHERE c.id_atendente = ? AND c.status_chamado = ? ORDER BY c.id"
+	return repo.queryChamados(query, idAtendente, estado)
+}
+
+func (repo *ChamadoRepositoryImpl) FindByBalcaoAndStatus(balcao entity.BalcaoEntity, status model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	query := chamadoSelect + " WHERE c.id_balcao = ? AND c.status_chamado = ? ORDER BY c.id"
+	return repo.queryChamados(query, balcao.ID, status)
+}
+
+func (repo *ChamadoRepositoryImpl) FindBySerial(serial string) (*entity.ChamadoEntity, error) {
+	query := chamadoSelect + " WHERE c.serial_number = ? ORDER BY c.data_creation DESC, c.id DESC LIMIT 1"
+	return repo.queryChamado(query, serial)
+}
+
+// FindHistoricoBySerial devolve todos os chamados do serial, do mais antigo
+// ao mais recente.
+func (repo *ChamadoRepositoryImpl) FindHistoricoBySerial(serial string) ([]entity.ChamadoEntity, error) {
+	query := chamadoSelect + " WHERE c.serial_number = ? ORDER BY c.data_creation, c.id"
+	return repo.queryChamados(query, serial)
+}
+
+func (repo *ChamadoRepositoryImpl) FindAllPaginated(page int, size int) ([]entity.ChamadoEntity, error) {
 	offset := page * size
-	query := "SELECT id, serial_number, customer_id FROM chamados LIMIT ? OFFSET ?"
+	return repo.queryChamados(chamadoSelect+" ORDER BY c.id LIMIT ? OFFSET ?", size, offset)
+}
+
+// colunasOrdenacao mapeia os campos ordenáveis para a expressão SQL usada
+// no ORDER BY e na comparação do cursor. O balcão nulo vira zero para que a
+// comparação por cursor funcione.
+var colunasOrdenacao = map[model.CampoOrdenacao]string{
+	model.OrdenarPorID:            "c.id",
+	model.OrdenarPorDataCreation:  "c.data_creation",
+	model.OrdenarPorStatusChamado: "c.status_chamado",
+	model.OrdenarPorBalcao:        "COALESCE(c.id_balcao, 0)",
+	model.OrdenarPorAtendente:     "c.user_atendente",
+	model.OrdenarPorCustomerID:    "c.customer_id",
+	model.OrdenarPorProduto:       "c.produto",
+	model.OrdenarPorSerialNumber:  "c.serial_number",
+}
+
+func (repo *ChamadoRepositoryImpl) BuscarChamados(consulta model.ConsultaChamados) ([]entity.ChamadoEntity, error) {
+	condicoes, args := condicoesFiltro(consulta.Filtro)
+
+	coluna, ok := colunasOrdenacao[consulta.Ordem.Campo]
+	if !ok {
+		coluna = colunasOrdenacao[model.OrdenarPorID]
+	}
+	sentido, comparacao := "ASC", ">"
+	if consulta.Ordem.Decrescente {
+		sentido, comparacao = "DESC", "<"
+	}
+
+	if consulta.Apos != nil {
+		condicoes = append(condicoes, "("+coluna+" "+comparacao+" ? OR ("+coluna+" = ? AND c.id "+comparacao+" ?))")
+		args = append(args, consulta.Apos.Valor, consulta.Apos.Valor, consulta.Apos.ID)
+	}
+
+	query := chamadoSelect + where(condicoes) + " ORDER BY " + coluna + " " + sentido + ", c.id " + sentido + " LIMIT ? OFFSET ?"
+	args = append(args, consulta.Limite, consulta.Offset)
+	return repo.queryChamados(query, args...)
+}
+
+func (repo *ChamadoRepositoryImpl) ContarChamados(filtro model.FiltroChamados) (int64, error) {
+	condicoes, args := condicoesFiltro(filtro)
 
-	rows, err := repo.db.Query(query, size, offset)
+	var total int64
+	err := repo.db.QueryRow("SELECT COUNT(*) FROM chamados c"+where(condicoes), args...).Scan(&total)
+	return total, err
+}
+
+// ContarPorCategoria agrupa os chamados do filtro pela categoria do produto,
+// em ordem alfabética de categoria.
+func (repo *ChamadoRepositoryImpl) ContarPorCategoria(filtro model.FiltroChamados) ([]model.TotalCategoria, error) {
+	condicoes, args := condicoesFiltro(filtro)
+
+	query := `SELECT COALESCE(p.categoria, ''), COUNT(*)
+	          FROM chamados c
+	          LEFT JOIN produtos p ON p.id = c.id_produto` + where(condicoes) + `
+	          GROUP BY 1
+	          ORDER BY 1`
+	rows, err := repo.db.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var chamados []dto.ChamadoDTO
+	var totais []model.TotalCategoria
 	for rows.Next() {
-		var chamado dto.ChamadoDTO
-		if err := rows.Scan(&chamado.ID, &chamado.SerialNumber, &chamado.CustomerID); err != nil {
+		var total model.TotalCategoria
+		if err := rows.Scan(&total.Categoria, &total.Total); err != nil {
+			return nil, err
+		}
+		totais = append(totais, total)
+	}
+	return totais, rows.Err()
+}
+
+func condicoesFiltro(filtro model.FiltroChamados) ([]string, []any) {
+	var (
+		condicoes []string
+		args      []any
+	)
+	if len(filtro.Status) > 0 {
+		marcadores := make([]string, len(filtro.Status))
+		for i, status := range filtro.Status {
+			marcadores[i] = "?"
+			args = append(args, status)
+		}
+		condicoes = append(condicoes, "c.status_chamado IN ("+strings.Join(marcadores, ", ")+")")
+	}
+	if filtro.IDBalcao != 0 {
+		condicoes = append(condicoes, "c.id_balcao = ?")
+		args = append(args, filtro.IDBalcao)
+	}
+	if filtro.IDAtendente != 0 {
+		condicoes = append(condicoes, "c.id_atendente = ?")
+		args = append(args, filtro.IDAtendente)
+	}
+	if filtro.UserAtendente != "" {
+		condicoes = append(condicoes, "c.user_atendente = ?")
+		args = append(args, filtro.UserAtendente)
+	}
+	if filtro.CustomerID != 0 {
+		condicoes = append(condicoes, "c.customer_id = ?")
+		args = append(args, filtro.CustomerID)
+	}
+	if filtro.IDProduto != 0 {
+		condicoes = append(condicoes, "c.id_produto = ?")
+		args = append(args, filtro.IDProduto)
+	}
+	if filtro.Categoria != "" {
+		condicoes = append(condicoes, "c.id_produto IN (SELECT p.id FROM produtos p WHERE p.categoria = ?)")
+		args = append(args, filtro.Categoria)
+	}
+	if filtro.Produto != "" {
+		condicoes = append(condicoes, "c.produto = ?")
+		args = append(args, filtro.Produto)
+	}
+	if filtro.SerialNumber != "" {
+		condicoes = append(condicoes, "c.serial_number = ?")
+		args = append(args, filtro.SerialNumber)
+	}
+	if !filtro.CriadoDe.IsZero() {
+		condicoes = append(condicoes, "c.data_creation >= ?")
+		args = append(args, filtro.CriadoDe)
+	}
+	if !filtro.CriadoAte.IsZero() {
+		condicoes = append(condicoes, "c.data_creation < ?")
+		args = append(args, filtro.CriadoAte)
+	}
+	return condicoes, args
+}
+
+func where(condicoes []string) string {
+	if len(condicoes) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(condicoes, " AND ")
+}
+
+func (repo *ChamadoRepositoryImpl) queryChamado(query string, args ...any) (*entity.ChamadoEntity, error) {
+	chamado, err := scanChamado(repo.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &chamado, nil
+}
+
+func (repo *ChamadoRepositoryImpl) queryChamados(query string, args ...any) ([]entity.ChamadoEntity, error) {
+	rows, err := repo.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chamados []entity.ChamadoEntity
+	for rows.Next() {
+		chamado, err := scanChamado(rows)
+		if err != nil {
 			return nil, err
 		}
 		chamados = append(chamados, chamado)
 	}
+	return chamados, rows.Err()
+}
 
-	return chamados, nil
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// garantiaOuPadrao grava chamados sem garantia informada como DESCONHECIDA,
+// o mesmo padrão da coluna.
+func garantiaOuPadrao(garantia model.StatusGarantia) model.StatusGarantia {
+	if garantia == "" {
+		return model.GarantiaDesconhecida
+	}
+	return garantia
 }
@@ -0,0 +1,260 @@
+package repository
+
+import (
+	"cmp"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"slices"
+	"sort"
+	"time"
+)
+
+type ChamadoRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewChamadoRepositoryMemoria(banco *BancoMemoria) *ChamadoRepositoryMemoria {
+	return &ChamadoRepositoryMemoria{banco: banco}
+}
+
+func (repo *ChamadoRepositoryMemoria) FindAll() ([]entity.ChamadoEntity, error) {
+	return repo.filtrar(func(entity.ChamadoEntity) bool { return true }, ordenarPorID), nil
+}
+
+func (repo *ChamadoRepositoryMemoria) Save(chamado *entity.ChamadoEntity) (*entity.ChamadoEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	if chamado.Versao != 0 && repo.banco.chamados[chamado.ID].Versao != chamado.Versao {
+		return nil, ErrVersaoDesatualizada
+	}
+	chamado.ID = proximoID(&repo.banco.ultimoChamadoID, chamado.ID)
+	chamado.Versao++
+	salvo := *chamado
+	salvo.Balcao = nil
+	salvo.PosicaoFila = 0
+	repo.banco.chamados[chamado.ID] = salvo
+	return chamado, nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindById(id int64) (*entity.ChamadoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	chamado, ok := repo.banco.chamados[id]
+	if !ok {
+		return nil, nil
+	}
+	chamado = repo.comBalcao(chamado)
+	return &chamado, nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindByCustomerId(customerId int64) ([]entity.ChamadoEntity, error) {
+	return repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return c.CustomerID == customerId
+	}, ordenarPorCriacaoDesc), nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindByAtendenteAndEstado(idAtendente int64, estado model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	return repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return c.IDAtendente == idAtendente && c.StatusChamado == estado
+	}, ordenarPorID), nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindByBalcaoAndStatus(balcao entity.BalcaoEntity, status model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	return repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return c.IDBalcao == balcao.ID && c.StatusChamado == status
+	}, ordenarPorID), nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindBySerial(serial string) (*entity.ChamadoEntity, error) {
+	chamados := repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return c.SerialNumber == serial
+	}, ordenarPorCriacaoDesc)
+	if len(chamados) == 0 {
+		return nil, nil
+	}
+	return &chamados[0], nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindHistoricoBySerial(serial string) ([]entity.ChamadoEntity, error) {
+	return repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return c.SerialNumber == serial
+	}, ordenarPorCriacao), nil
+}
+
+func (repo *ChamadoRepositoryMemoria) FindAllPaginated(page int, size int) ([]entity.ChamadoEntity, error) {
+	chamados := repo.filtrar(func(entity.ChamadoEntity) bool { return true }, ordenarPorID)
+
+	offset := page * size
+	if offset < 0 || size < 0 || offset >= len(chamados) {
+		return nil, nil
+	}
+	fim := offset + size
+	if fim > len(chamados) {
+		fim = len(chamados)
+	}
+	return chamados[offset:fim], nil
+}
+
+func (repo *ChamadoRepositoryMemoria) BuscarChamados(consulta model.ConsultaChamados) ([]entity.ChamadoEntity, error) {
+	ordem := consulta.Ordem
+	if !ordem.Campo.Valido() {
+		ordem.Campo = model.OrdenarPorID
+	}
+	chamados := repo.filtrar(func(c entity.ChamadoEntity) bool {
+		return repo.atendeFiltro(c, consulta.Filtro) &&
+			(consulta.Apos == nil || compararPosicao(ordem, c, *consulta.Apos) > 0)
+	}, func(a, b entity.ChamadoEntity) bool {
+		return compararPosicao(ordem, a, posicao(ordem.Campo, b)) < 0
+	})
+
+	if consulta.Offset >= len(chamados) {
+		return nil, nil
+	}
+	chamados = chamados[consulta.Offset:]
+	if consulta.Limite >= 0 && consulta.Limite < len(chamados) {
+		chamados = chamados[:consulta.Limite]
+	}
+	return chamados, nil
+}
+
+func (repo *ChamadoRepositoryMemoria) ContarChamados(filtro model.FiltroChamados) (int64, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var total int64
+	for _, chamado := range repo.banco.chamados {
+		if repo.atendeFiltro(chamado, filtro) {
+			total++
+		}
+	}
+	return total, nil
+}
+
+func (repo *ChamadoRepositoryMemoria) ContarPorCategoria(filtro model.FiltroChamados) ([]model.TotalCategoria, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	porCategoria := map[string]int64{}
+	for _, chamado := range repo.banco.chamados {
+		if repo.atendeFiltro(chamado, filtro) {
+			porCategoria[repo.banco.produtos[chamado.IDProduto].Categoria]++
+		}
+	}
+
+	var totais []model.TotalCategoria
+	for categoria, total := range porCategoria {
+		totais = append(totais, model.TotalCategoria{Categoria: categoria, Total: total})
+	}
+	slices.SortFunc(totais, func(a, b model.TotalCategoria) int { return cmp.Compare(a.Categoria, b.Categoria) })
+	return totais, nil
+}
+
+// atendeFiltro consulta os produtos do banco; quem chama já deve ter a
+// trava de leitura.
+func (repo *ChamadoRepositoryMemoria) atendeFiltro(c entity.ChamadoEntity, filtro model.FiltroChamados) bool {
+	switch {
+	case len(filtro.Status) > 0 && !slices.Contains(filtro.Status, c.StatusChamado):
+		return false
+	case filtro.IDBalcao != 0 && c.IDBalcao != filtro.IDBalcao:
+		return false
+	case filtro.IDAtendente != 0 && c.IDAtendente != filtro.IDAtendente:
+		return false
+	case filtro.UserAtendente != "" && c.UserAtendente != filtro.UserAtendente:
+		return false
+	case filtro.CustomerID != 0 && c.CustomerID != filtro.CustomerID:
+		return false
+	case filtro.IDProduto != 0 && c.IDProduto != filtro.IDProduto:
+		return false
+	case filtro.Categoria != "" && (c.IDProduto == 0 || repo.banco.produtos[c.IDProduto].Categoria != filtro.Categoria):
+		return false
+	case filtro.Produto != "" && c.Produto != filtro.Produto:
+		return false
+	case filtro.SerialNumber != "" && c.SerialNumber != filtro.SerialNumber:
+		return false
+	case !filtro.CriadoDe.IsZero() && c.DataCreation.Before(filtro.CriadoDe):
+		return false
+	case !filtro.CriadoAte.IsZero() && !c.DataCreation.Before(filtro.CriadoAte):
+		return false
+	}
+	return true
+}
+
+func posicao(campo model.CampoOrdenacao, c entity.ChamadoEntity) model.PosicaoChamado {
+	return model.PosicaoChamado{Valor: campo.Valor(c.Chamado), ID: c.ID}
+}
+
+// compararPosicao devolve um número negativo se o chamado vem antes da
+// posição na ordem, zero se está nela e positivo se vem depois.
+func compararPosicao(ordem model.OrdemChamados, c entity.ChamadoEntity, p model.PosicaoChamado) int {
+	resultado := compararValores(ordem.Campo.Valor(c.Chamado), p.Valor)
+	if resultado == 0 {
+		resultado = cmp.Compare(c.ID, p.ID)
+	}
+	if ordem.Decrescente {
+		return -resultado
+	}
+	return resultado
+}
+
+func compararValores(a, b any) int {
+	switch va := a.(type) {
+	case int64:
+		vb, _ := b.(int64)
+		return cmp.Compare(va, vb)
+	case string:
+		vb, _ := b.(string)
+		return cmp.Compare(va, vb)
+	case time.Time:
+		vb, _ := b.(time.Time)
+		return va.Compare(vb)
+	}
+	return 0
+}
+
+func (repo *ChamadoRepositoryMemoria) filtrar(filtro func(entity.ChamadoEntity) bool, ordem func(a, b entity.ChamadoEntity) bool) []entity.ChamadoEntity {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var chamados []entity.ChamadoEntity
+	for _, chamado := range repo.banco.chamados {
+		if filtro(chamado) {
+			chamados = append(chamados, repo.comBalcao(chamado))
+		}
+	}
+	sort.Slice(chamados, func(i, j int) bool { return ordem(chamados[i], chamados[j]) })
+	return chamados
+}
+
+func (repo *ChamadoRepositoryMemoria) comBalcao(chamado entity.ChamadoEntity) entity.ChamadoEntity {
+	if balcao, ok := repo.banco.balcoes[chamado.IDBalcao]; ok {
+		chamado.Balcao = &model.Balcao{
+			ID:              balcao.ID,
+			IDAtendente:     balcao.IDAtendente,
+			NomeAtendente:   balcao.NomeAtendente,
+			FilaAtendimento: balcao.FilaAtendimento,
+			Capacidade:      balcao.Capacidade,
+			Ativo:           balcao.Ativo,
+			Versao:          balcao.Versao,
+		}
+	}
+	return chamado
+}
+
+func ordenarPorID(a, b entity.ChamadoEntity) bool {
+	return a.ID < b.ID
+}
+
+func ordenarPorCriacao(a, b entity.ChamadoEntity) bool {
+	if !a.DataCreation.Equal(b.DataCreation) {
+		return a.DataCreation.Before(b.DataCreation)
+	}
+	return a.ID < b.ID
+}
+
+func ordenarPorCriacaoDesc(a, b entity.ChamadoEntity) bool {
+	if !a.DataCreation.Equal(b.DataCreation) {
+		return a.DataCreation.After(b.DataCreation)
+	}
+	return a.ID > b.ID
+}
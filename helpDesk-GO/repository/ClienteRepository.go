@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"helpdesk/entity"
+)
+
+type ClienteRepository interface {
+	FindAll() ([]entity.ClienteEntity, error)
+	FindById(id int64) (*entity.ClienteEntity, error)
+	FindByDocumento(documento string) (*entity.ClienteEntity, error)
+	Save(cliente entity.ClienteEntity) (entity.ClienteEntity, error)
+}
+
+type ClienteRepositoryImpl struct {
+	db executor
+}
+
+func NewClienteRepository(db *sql.DB) *ClienteRepositoryImpl {
+	return &ClienteRepositoryImpl{db: db}
+}
+
+const clienteSelect = "SELECT c.id, c.nome, c.documento, c.email, c.telefone FROM clientes c"
+
+func scanCliente(row rowScanner) (entity.ClienteEntity, error) {
+	var (
+		cliente   entity.ClienteEntity
+		documento sql.NullString
+	)
+	err := row.Scan(&cliente.ID, &cliente.Nome, &documento, &cliente.Email, &cliente.Telefone)
+	cliente.Documento = documento.String
+	return cliente, err
+}
+
+func (repo *ClienteRepositoryImpl) FindAll() ([]entity.ClienteEntity, error) {
+	rows, err := repo.db.Query(clienteSelect + " ORDER BY c.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var clientes []entity.ClienteEntity
+	for rows.Next() {
+		cliente, err := scanCliente(rows)
+		if err != nil {
+			return nil, err
+		}
+		clientes = append(clientes, cliente)
+	}
+	return clientes, rows.Err()
+}
+
+func (repo *ClienteRepositoryImpl) FindById(id int64) (*entity.ClienteEntity, error) {
+	return repo.queryCliente(clienteSelect+" WHERE c.id = ?", id)
+}
+
+func (repo *ClienteRepositoryImpl) FindByDocumento(documento string) (*entity.ClienteEntity, error) {
+	return repo.queryCliente(clienteSelect+" WHERE c.documento = ?", documento)
+}
+
+// Save insere clientes sem ID e atualiza os demais. O documento vazio é
+// gravado como NULL, de modo que só um documento já usado por outro cliente
+// resulta em ErrRegistroDuplicado.
+func (repo *ClienteRepositoryImpl) Save(cliente entity.ClienteEntity) (entity.ClienteEntity, error) {
+	if cliente.ID != 0 {
+		query := "UPDATE clientes SET nome = ?, documento = NULLIF(?, ''), email = ?, telefone = ? WHERE id = ?"
+		if _, err := repo.db.Exec(query, cliente.Nome, cliente.Documento, cliente.Email, cliente.Telefone, cliente.ID); err != nil {
+			return entity.ClienteEntity{}, traduzirDuplicado(err)
+		}
+		return cliente, nil
+	}
+
+	query := "INSERT INTO clientes (nome, documento, email, telefone) VALUES (?, NULLIF(?, ''), ?, ?)"
+	result, err := repo.db.Exec(query, cliente.Nome, cliente.Documento, cliente.Email, cliente.Telefone)
+	if err != nil {
+		return entity.ClienteEntity{}, traduzirDuplicado(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.ClienteEntity{}, err
+	}
+	cliente.ID = id
+	return cliente, nil
+}
+
+func (repo *ClienteRepositoryImpl) queryCliente(query string, args ...any) (*entity.ClienteEntity, error) {
+	cliente, err := scanCliente(repo.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &cliente, nil
+}
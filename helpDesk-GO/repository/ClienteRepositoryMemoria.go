@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"cmp"
+	"helpdesk/entity"
+	"slices"
+)
+
+type ClienteRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewClienteRepositoryMemoria(banco *BancoMemoria) *ClienteRepositoryMemoria {
+	return &ClienteRepositoryMemoria{banco: banco}
+}
+
+func (repo *ClienteRepositoryMemoria) FindAll() ([]entity.ClienteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var clientes []entity.ClienteEntity
+	for _, cliente := range repo.banco.clientes {
+		clientes = append(clientes, cliente)
+	}
+	slices.SortFunc(clientes, func(a, b entity.ClienteEntity) int { return cmp.Compare(a.ID, b.ID) })
+	return clientes, nil
+}
+
+func (repo *ClienteRepositoryMemoria) FindById(id int64) (*entity.ClienteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	cliente, ok := repo.banco.clientes[id]
+	if !ok {
+		return nil, nil
+	}
+	return &cliente, nil
+}
+
+func (repo *ClienteRepositoryMemoria) FindByDocumento(documento string) (*entity.ClienteEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for _, cliente := range repo.banco.clientes {
+		if documento != "" && cliente.Documento == documento {
+			return &cliente, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *ClienteRepositoryMemoria) Save(cliente entity.ClienteEntity) (entity.ClienteEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	if cliente.Documento != "" {
+		for _, existente := range repo.banco.clientes {
+			if existente.ID != cliente.ID && existente.Documento == cliente.Documento {
+				return entity.ClienteEntity{}, ErrRegistroDuplicado
+			}
+		}
+	}
+	cliente.ID = proximoID(&repo.banco.ultimoClienteID, cliente.ID)
+	repo.banco.clientes[cliente.ID] = cliente
+	return cliente, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"database/sql"
+	"helpdesk/entity"
+)
+
+type DispositivoRepository interface {
+	FindBySerial(serial string) (*entity.DispositivoEntity, error)
+	Save(dispositivo entity.DispositivoEntity) (entity.DispositivoEntity, error)
+}
+
+type DispositivoRepositoryImpl struct {
+	db executor
+}
+
+func NewDispositivoRepository(db *sql.DB) *DispositivoRepositoryImpl {
+	return &DispositivoRepositoryImpl{db: db}
+}
+
+func (repo *DispositivoRepositoryImpl) FindBySerial(serial string) (*entity.DispositivoEntity, error) {
+	query := "SELECT id, serial_number, produto, customer_id, data_compra FROM dispositivos WHERE serial_number = ?"
+
+	var (
+		dispositivo entity.DispositivoEntity
+		dataCompra  sql.NullTime
+	)
+	err := repo.db.QueryRow(query, serial).Scan(&dispositivo.ID, &dispositivo.SerialNumber, &dispositivo.Produto,
+		&dispositivo.CustomerID, &dataCompra)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	dispositivo.DataCompra = dataCompra.Time
+	return &dispositivo, nil
+}
+
+// Save grava como NULL a data de compra não informada. Um serial já
+// cadastrado resulta em ErrRegistroDuplicado.
+func (repo *DispositivoRepositoryImpl) Save(dispositivo entity.DispositivoEntity) (entity.DispositivoEntity, error) {
+	if dispositivo.ID != 0 {
+		query := "UPDATE dispositivos SET serial_number = ?, produto = ?, customer_id = ?, data_compra = ? WHERE id = ?"
+		_, err := repo.db.Exec(query, dispositivo.SerialNumber, dispositivo.Produto, dispositivo.CustomerID,
+			nullTime(dispositivo.DataCompra), dispositivo.ID)
+		if err != nil {
+			return entity.DispositivoEntity{}, traduzirDuplicado(err)
+		}
+		return dispositivo, nil
+	}
+
+	query := "INSERT INTO dispositivos (serial_number, produto, customer_id, data_compra) VALUES (?, ?, ?, ?)"
+	result, err := repo.db.Exec(query, dispositivo.SerialNumber, dispositivo.Produto, dispositivo.CustomerID,
+		nullTime(dispositivo.DataCompra))
+	if err != nil {
+		return entity.DispositivoEntity{}, traduzirDuplicado(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.DispositivoEntity{}, err
+	}
+	dispositivo.ID = id
+	return dispositivo, nil
+}
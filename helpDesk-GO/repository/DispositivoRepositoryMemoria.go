@@ -0,0 +1,36 @@
+package repository
+
+import "helpdesk/entity"
+
+type DispositivoRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewDispositivoRepositoryMemoria(banco *BancoMemoria) *DispositivoRepositoryMemoria {
+	return &DispositivoRepositoryMemoria{banco: banco}
+}
+
+func (repo *DispositivoRepositoryMemoria) FindBySerial(serial string) (*entity.DispositivoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for _, dispositivo := range repo.banco.dispositivos {
+		if dispositivo.SerialNumber == serial {
+			return &dispositivo, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *DispositivoRepositoryMemoria) Save(dispositivo entity.DispositivoEntity) (entity.DispositivoEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	for _, existente := range repo.banco.dispositivos {
+		if existente.ID != dispositivo.ID && existente.SerialNumber == dispositivo.SerialNumber {
+			return entity.DispositivoEntity{}, ErrRegistroDuplicado
+		}
+	}
+	dispositivo.ID = proximoID(&repo.banco.ultimoDispositivoID, dispositivo.ID)
+	repo.banco.dispositivos[dispositivo.ID] = dispositivo
+	return dispositivo, nil
+}
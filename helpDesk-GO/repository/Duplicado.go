@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+)
+
+// ErrRegistroDuplicado é devolvido por Save quando o registro viola uma
+// chave única, como o login do atendente ou o atendente de um balcão.
+var ErrRegistroDuplicado = errors.New("registro duplicado")
+
+const codigoEntradaDuplicada = 1062
+
+// traduzirDuplicado converte a violação de chave única do MySQL em
+// ErrRegistroDuplicado. Todo Save que grava uma coluna única passa o erro do
+// INSERT ou UPDATE por ela.
+func traduzirDuplicado(err error) error {
+	var erroMySQL *mysql.MySQLError
+	if errors.As(err, &erroMySQL) && erroMySQL.Number == codigoEntradaDuplicada {
+		return ErrRegistroDuplicado
+	}
+	return err
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"database/sql"
+	"helpdesk/entity"
+)
+
+type ProdutoRepository interface {
+	FindAll() ([]entity.ProdutoEntity, error)
+	FindById(id int64) (*entity.ProdutoEntity, error)
+	FindBySku(sku string) (*entity.ProdutoEntity, error)
+	Save(produto entity.ProdutoEntity) (entity.ProdutoEntity, error)
+}
+
+type ProdutoRepositoryImpl struct {
+	db executor
+}
+
+func NewProdutoRepository(db *sql.DB) *ProdutoRepositoryImpl {
+	return &ProdutoRepositoryImpl{db: db}
+}
+
+const produtoSelect = "SELECT p.id, p.sku, p.nome, p.categoria, p.id_balcao_padrao FROM produtos p"
+
+func scanProduto(row rowScanner) (entity.ProdutoEntity, error) {
+	var (
+		produto        entity.ProdutoEntity
+		idBalcaoPadrao sql.NullInt64
+	)
+	err := row.Scan(&produto.ID, &produto.SKU, &produto.Nome, &produto.Categoria, &idBalcaoPadrao)
+	produto.IDBalcaoPadrao = idBalcaoPadrao.Int64
+	return produto, err
+}
+
+func (repo *ProdutoRepositoryImpl) FindAll() ([]entity.ProdutoEntity, error) {
+	rows, err := repo.db.Query(produtoSelect + " ORDER BY p.id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var produtos []entity.ProdutoEntity
+	for rows.Next() {
+		produto, err := scanProduto(rows)
+		if err != nil {
+			return nil, err
+		}
+		produtos = append(produtos, produto)
+	}
+	return produtos, rows.Err()
+}
+
+func (repo *ProdutoRepositoryImpl) FindById(id int64) (*entity.ProdutoEntity, error) {
+	return repo.queryProduto(produtoSelect+" WHERE p.id = ?", id)
+}
+
+func (repo *ProdutoRepositoryImpl) FindBySku(sku string) (*entity.ProdutoEntity, error) {
+	return repo.queryProduto(produtoSelect+" WHERE p.sku = ?", sku)
+}
+
+// Save grava como NULL o produto sem balcão padrão. Um SKU já usado por
+// outro produto resulta em ErrRegistroDuplicado.
+func (repo *ProdutoRepositoryImpl) Save(produto entity.ProdutoEntity) (entity.ProdutoEntity, error) {
+	if produto.ID != 0 {
+		query := "UPDATE produtos SET sku = ?, nome = ?, categoria = ?, id_balcao_padrao = NULLIF(?, 0) WHERE id = ?"
+		_, err := repo.db.Exec(query, produto.SKU, produto.Nome, produto.Categoria, produto.IDBalcaoPadrao, produto.ID)
+		if err != nil {
+			return entity.ProdutoEntity{}, traduzirDuplicado(err)
+		}
+		return produto, nil
+	}
+
+	query := "INSERT INTO produtos (sku, nome, categoria, id_balcao_padrao) VALUES (?, ?, ?, NULLIF(?, 0))"
+	result, err := repo.db.Exec(query, produto.SKU, produto.Nome, produto.Categoria, produto.IDBalcaoPadrao)
+	if err != nil {
+		return entity.ProdutoEntity{}, traduzirDuplicado(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entity.ProdutoEntity{}, err
+	}
+	produto.ID = id
+	return produto, nil
+}
+
+func (repo *ProdutoRepositoryImpl) queryProduto(query string, args ...any) (*entity.ProdutoEntity, error) {
+	produto, err := scanProduto(repo.db.QueryRow(query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &produto, nil
+}
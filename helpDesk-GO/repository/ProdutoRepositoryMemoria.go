@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"cmp"
+	"helpdesk/entity"
+	"slices"
+	"strings"
+)
+
+type ProdutoRepositoryMemoria struct {
+	banco *BancoMemoria
+}
+
+func NewProdutoRepositoryMemoria(banco *BancoMemoria) *ProdutoRepositoryMemoria {
+	return &ProdutoRepositoryMemoria{banco: banco}
+}
+
+func (repo *ProdutoRepositoryMemoria) FindAll() ([]entity.ProdutoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	var produtos []entity.ProdutoEntity
+	for _, produto := range repo.banco.produtos {
+		produtos = append(produtos, produto)
+	}
+	slices.SortFunc(produtos, func(a, b entity.ProdutoEntity) int { return cmp.Compare(a.ID, b.ID) })
+	return produtos, nil
+}
+
+func (repo *ProdutoRepositoryMemoria) FindById(id int64) (*entity.ProdutoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	produto, ok := repo.banco.produtos[id]
+	if !ok {
+		return nil, nil
+	}
+	return &produto, nil
+}
+
+// FindBySku não diferencia maiúsculas, como a collation da tabela no MySQL.
+func (repo *ProdutoRepositoryMemoria) FindBySku(sku string) (*entity.ProdutoEntity, error) {
+	repo.banco.mu.RLock()
+	defer repo.banco.mu.RUnlock()
+
+	for _, produto := range repo.banco.produtos {
+		if strings.EqualFold(produto.SKU, sku) {
+			return &produto, nil
+		}
+	}
+	return nil, nil
+}
+
+func (repo *ProdutoRepositoryMemoria) Save(produto entity.ProdutoEntity) (entity.ProdutoEntity, error) {
+	defer repo.banco.travarEscrita()()
+
+	for _, existente := range repo.banco.produtos {
+		if existente.ID != produto.ID && strings.EqualFold(existente.SKU, produto.SKU) {
+			return entity.ProdutoEntity{}, ErrRegistroDuplicado
+		}
+	}
+	produto.ID = proximoID(&repo.banco.ultimoProdutoID, produto.ID)
+	repo.banco.produtos[produto.ID] = produto
+	return produto, nil
+}
@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// executor é o que os repositórios SQL usam para falar com o banco; tanto
+// *sql.DB quanto *sql.Tx o satisfazem.
+type executor interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+type Repositorios struct {
+	Chamados     ChamadoRepository
+	Balcoes      BalcaoRepository
+	Atendimentos AtendimentoRepository
+	Atendentes   AtendenteRepository
+	Clientes     ClienteRepository
+	Dispositivos DispositivoRepository
+	Produtos     ProdutoRepository
+}
+
+// UnidadeDeTrabalho executa operações que envolvem mais de um repositório
+// de forma atômica: se a função retornar erro (ou entrar em pânico), nada do
+// que foi gravado por ela permanece.
+type UnidadeDeTrabalho interface {
+	Repositorios() Repositorios
+	Executar(fn func(repos Repositorios) error) error
+}
+
+type UnidadeDeTrabalhoSQL struct {
+	db *sql.DB
+}
+
+func NovaUnidadeDeTrabalhoSQL(db *sql.DB) *UnidadeDeTrabalhoSQL {
+	return &UnidadeDeTrabalhoSQL{db: db}
+}
+
+func (u *UnidadeDeTrabalhoSQL) Repositorios() Repositorios {
+	return repositoriosSQL(u.db)
+}
+
+func (u *UnidadeDeTrabalhoSQL) Executar(fn func(repos Repositorios) error) (err error) {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erro ao iniciar transação: %w", err)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			tx.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(repositoriosSQL(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("erro ao confirmar transação: %w", err)
+	}
+	return nil
+}
+
+func repositoriosSQL(db executor) Repositorios {
+	return Repositorios{
+		Chamados:     &ChamadoRepositoryImpl{db: db},
+		Balcoes:      &BalcaoRepositoryImpl{db: db},
+		Atendimentos: &ListaAtendimentoRepositoryImpl{db: db},
+		Atendentes:   &AtendenteRepositoryImpl{db: db},
+		Clientes:     &ClienteRepositoryImpl{db: db},
+		Dispositivos: &DispositivoRepositoryImpl{db: db},
+		Produtos:     &ProdutoRepositoryImpl{db: db},
+	}
+}
+
+type UnidadeDeTrabalhoMemoria struct {
+	banco *BancoMemoria
+}
+
+func NovaUnidadeDeTrabalhoMemoria(banco *BancoMemoria) *UnidadeDeTrabalhoMemoria {
+	return &UnidadeDeTrabalhoMemoria{banco: banco}
+}
+
+func (u *UnidadeDeTrabalhoMemoria) Repositorios() Repositorios {
+	return repositoriosMemoria(u.banco)
+}
+
+// Executar roda fn sobre uma cópia do banco e só a publica se fn terminar sem
+// erro. As transações e as gravações feitas fora delas são serializadas, de
+// modo que nenhuma escrita se perde ao publicar a cópia.
+func (u *UnidadeDeTrabalhoMemoria) Executar(fn func(repos Repositorios) error) error {
+	u.banco.escrita.Lock()
+	defer u.banco.escrita.Unlock()
+
+	copia := u.banco.copiar()
+	if err := fn(repositoriosMemoria(copia)); err != nil {
+		return err
+	}
+	u.banco.restaurar(copia)
+	return nil
+}
+
+func repositoriosMemoria(banco *BancoMemoria) Repositorios {
+	return Repositorios{
+		Chamados:     NewChamadoRepositoryMemoria(banco),
+		Balcoes:      NewBalcaoRepositoryMemoria(banco),
+		Atendimentos: NewAtendimentoRepositoryMemoria(banco),
+		Atendentes:   NewAtendenteRepositoryMemoria(banco),
+		Clientes:     NewClienteRepositoryMemoria(banco),
+		Dispositivos: NewDispositivoRepositoryMemoria(banco),
+		Produtos:     NewProdutoRepositoryMemoria(banco),
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "errors"
+
+// ErrVersaoDesatualizada é devolvido por Save quando a versão do registro
+// informado não é mais a salva, ou seja, outra escrita aconteceu desde a
+// leitura. Registros com versão zero são tratados como novos.
+var ErrVersaoDesatualizada = errors.New("versão do registro desatualizada")
+
+func conferirVersao(afetadas int64) error {
+	if afetadas == 0 {
+		return ErrVersaoDesatualizada
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"helpdesk/Exception"
+	"log/slog"
+	"net/http"
+)
+
+const tipoConteudoProblema = "application/problem+json"
+
+// Problema é o corpo de erro da API no formato RFC 7807, acrescido do código
+// estável do erro e, em erros de validação, dos campos inválidos.
+type Problema struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Codigo   string            `json:"codigo"`
+	Uri      string            `json:"uri,omitempty"`
+	Campos   map[string]string `json:"campos,omitempty"`
+}
+
+var statusPorTipo = map[Exception.Tipo]int{
+	Exception.TipoNaoEncontrado:          http.StatusNotFound,
+	Exception.TipoConflito:               http.StatusConflict,
+	Exception.TipoProibido:               http.StatusForbidden,
+	Exception.TipoValidacao:              http.StatusBadRequest,
+	Exception.TipoCapacidadeExcedida:     http.StatusConflict,
+	Exception.TipoPrecondicaoFalhou:      http.StatusPreconditionFailed,
+	Exception.TipoPrecondicaoObrigatoria: http.StatusPreconditionRequired,
+}
+
+// ErrosMiddleware responde com um Problema o último erro registrado pelo
+// handler via c.Error, se ele ainda não tiver escrito uma resposta.
+func ErrosMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+		escreverProblema(c, c.Errors.Last().Err)
+	}
+}
+
+func recuperarPanico(c *gin.Context, recuperado any) {
+	slog.Error("pânico ao atender requisição", "caminho", c.Request.URL.Path, "erro", recuperado)
+	escreverProblema(c, errors.New("pânico"))
+	c.Abort()
+}
+
+func escreverProblema(c *gin.Context, err error) {
+	problema := NovoProblema(err)
+	problema.Instance = c.Request.URL.Path
+	if problema.Status == http.StatusInternalServerError {
+		slog.Error("erro ao atender requisição", "caminho", c.Request.URL.Path, "erro", err)
+	}
+
+	c.Header("Content-Type", tipoConteudoProblema)
+	c.JSON(problema.Status, problema)
+}
+
+func NovoProblema(err error) Problema {
+	var dominio Exception.ErroDominio
+	if !errors.As(err, &dominio) {
+		return Problema{
+			Type:   "about:blank",
+			Title:  http.StatusText(http.StatusInternalServerError),
+			Status: http.StatusInternalServerError,
+			Detail: "Erro interno do servidor.",
+			Codigo: Exception.CodigoErroInterno,
+		}
+	}
+
+	status, ok := statusPorTipo[dominio.Tipo()]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	problema := Problema{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: dominio.Error(),
+		Codigo: dominio.CodigoErro(),
+	}
+
+	switch e := dominio.(type) {
+	case *Exception.ConflictException:
+		problema.Detail = e.Message
+		problema.Uri = e.Uri
+	case *Exception.ForbiddenException:
+		problema.Detail = e.Message
+		problema.Uri = e.Uri
+	case *Exception.ValidationException:
+		problema.Detail = e.Message
+		problema.Campos = e.Campos
+	}
+	return problema
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"helpdesk/controller"
+)
+
+func NovoRouter(chamadoController *controller.ChamadoController, balcaoController *controller.BalcaoController, atendenteController *controller.AtendenteController, clienteController *controller.ClienteController, dispositivoController *controller.DispositivoController, produtoController *controller.ProdutoController) *gin.Engine {
+	controller.RegistrarValidacoes()
+
+	router := gin.New()
+	router.Use(gin.Logger(), gin.CustomRecovery(recuperarPanico), ErrosMiddleware())
+
+	api := router.Group("/api/v1")
+
+	chamados := api.Group("/chamados")
+	chamados.POST("", chamadoController.CriarChamado)
+	chamados.GET("", chamadoController.ListarChamados)
+	chamados.GET("/por-categoria", chamadoController.ContarPorCategoria)
+	chamados.GET("/:id", chamadoController.DetalharChamado)
+	chamados.PUT("/:id", chamadoController.EditarChamados)
+	chamados.PATCH("/:id", chamadoController.AtualizarChamadoParcial)
+	chamados.POST("/:id/assumir", chamadoController.AssumirChamado)
+	chamados.POST("/:id/resolver", chamadoController.ResolverChamado)
+	chamados.POST("/:id/fechar", chamadoController.FecharChamado)
+	chamados.POST("/:id/reabrir", chamadoController.ReabrirChamado)
+
+	balcoes := api.Group("/balcoes")
+	balcoes.POST("", balcaoController.CadastrarBalcao)
+	balcoes.GET("", balcaoController.ListarBalcoes)
+	balcoes.GET("/:id", balcaoController.DetalharBalcao)
+	balcoes.PUT("/:id", balcaoController.EditarBalcao)
+	balcoes.PATCH("/:id", balcaoController.AtualizarBalcaoParcial)
+	balcoes.DELETE("/:id", balcaoController.DesativarBalcao)
+
+	atendentes := api.Group("/atendentes")
+	atendentes.POST("", atendenteController.CadastrarAtendente)
+	atendentes.GET("", atendenteController.ListarAtendentes)
+	atendentes.GET("/:id", atendenteController.DetalharAtendente)
+	atendentes.PUT("/:id", atendenteController.EditarAtendente)
+	atendentes.DELETE("/:id", atendenteController.DesativarAtendente)
+
+	clientes := api.Group("/clientes")
+	clientes.POST("", clienteController.CadastrarCliente)
+	clientes.GET("", clienteController.ListarClientes)
+	clientes.GET("/:id", clienteController.DetalharCliente)
+	clientes.PUT("/:id", clienteController.EditarCliente)
+	clientes.GET("/:id/chamados", chamadoController.ListarChamadosDoCliente)
+
+	dispositivos := api.Group("/dispositivos")
+	dispositivos.POST("", dispositivoController.CadastrarDispositivo)
+	dispositivos.GET("/:serial", dispositivoController.DetalharDispositivo)
+	dispositivos.PUT("/:serial", dispositivoController.EditarDispositivo)
+	dispositivos.GET("/:serial/historico", dispositivoController.HistoricoDispositivo)
+
+	produtos := api.Group("/produtos")
+	produtos.POST("", produtoController.CadastrarProduto)
+	produtos.GET("", produtoController.ListarProdutos)
+	produtos.GET("/:id", produtoController.DetalharProduto)
+	produtos.PUT("/:id", produtoController.EditarProduto)
+
+	return router
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+type Opcoes struct {
+	Addr            string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type Server struct {
+	httpServer      *http.Server
+	shutdownTimeout time.Duration
+}
+
+func NovoServer(opcoes Opcoes, handler http.Handler) *Server {
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              opcoes.Addr,
+			Handler:           handler,
+			ReadHeaderTimeout: opcoes.ReadTimeout,
+			ReadTimeout:       opcoes.ReadTimeout,
+			WriteTimeout:      opcoes.WriteTimeout,
+		},
+		shutdownTimeout: opcoes.ShutdownTimeout,
+	}
+}
+
+// Run atende requisições até o contexto ser cancelado e então aguarda as
+// requisições em andamento terminarem antes de retornar.
+func (s *Server) Run(ctx context.Context) error {
+	erros := make(chan error, 1)
+	go func() {
+		log.Printf("Servidor HTTP escutando em %s", s.httpServer.Addr)
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			erros <- err
+		}
+		close(erros)
+	}()
+
+	select {
+	case err := <-erros:
+		if err != nil {
+			return fmt.Errorf("erro ao iniciar servidor HTTP: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+	}
+
+	log.Println("Encerrando servidor HTTP...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("erro ao encerrar servidor HTTP: %w", err)
+	}
+	return nil
+}
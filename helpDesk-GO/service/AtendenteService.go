@@ -0,0 +1,272 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"strings"
+)
+
+type AtendenteService struct {
+	AtendenteRepository repository.AtendenteRepository
+	BalcaoRepository    repository.BalcaoRepository
+	ChamadoRepository   repository.ChamadoRepository
+	unidade             repository.UnidadeDeTrabalho
+}
+
+// NovoAtendenteService monta o serviço sobre repositórios avulsos, sem
+// transação entre eles. Em produção use NovoAtendenteServiceTransacional.
+func NovoAtendenteService(atendenteRepo repository.AtendenteRepository, balcaoRepo repository.BalcaoRepository, chamadoRepo repository.ChamadoRepository) *AtendenteService {
+	return NovoAtendenteServiceTransacional(semTransacao{repository.Repositorios{
+		Atendentes: atendenteRepo,
+		Balcoes:    balcaoRepo,
+		Chamados:   chamadoRepo,
+	}})
+}
+
+func NovoAtendenteServiceTransacional(unidade repository.UnidadeDeTrabalho) *AtendenteService {
+	repos := unidade.Repositorios()
+	return &AtendenteService{
+		AtendenteRepository: repos.Atendentes,
+		BalcaoRepository:    repos.Balcoes,
+		ChamadoRepository:   repos.Chamados,
+		unidade:             unidade,
+	}
+}
+
+// emTransacao executa fn com uma cópia do serviço cujos repositórios
+// participam da mesma transação. Serviços montados sem unidade de trabalho
+// usam os próprios repositórios.
+func (as *AtendenteService) emTransacao(fn func(tx *AtendenteService) error) error {
+	if as.unidade == nil {
+		return fn(as)
+	}
+	return as.unidade.Executar(func(repos repository.Repositorios) error {
+		tx := *as
+		tx.AtendenteRepository = repos.Atendentes
+		tx.BalcaoRepository = repos.Balcoes
+		tx.ChamadoRepository = repos.Chamados
+		tx.unidade = semTransacao{repos}
+		return fn(&tx)
+	})
+}
+
+func (as *AtendenteService) CadastrarAtendente(atendenteDTO *dto.CriarAtendenteDTO) (*entity.AtendenteEntity, error) {
+	if atendenteDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O atendente não pode ser nulo."}
+	}
+
+	existente, err := as.AtendenteRepository.FindByLogin(atendenteDTO.Login)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar atendente %q: %w", atendenteDTO.Login, err)
+	}
+	if existente != nil {
+		return nil, loginDuplicado(atendenteDTO.Login)
+	}
+
+	atendente, err := as.AtendenteRepository.Save(entity.AtendenteEntity{
+		Atendente: model.Atendente{
+			Login:       atendenteDTO.Login,
+			Nome:        atendenteDTO.Nome,
+			Ativo:       true,
+			Habilidades: normalizarHabilidades(atendenteDTO.Habilidades),
+		},
+	})
+	if errors.Is(err, repository.ErrRegistroDuplicado) {
+		return nil, loginDuplicado(atendenteDTO.Login)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar atendente: %w", err)
+	}
+	return &atendente, nil
+}
+
+func (as *AtendenteService) ListarAtendentes() ([]entity.AtendenteEntity, error) {
+	return as.AtendenteRepository.FindAll()
+}
+
+func (as *AtendenteService) DetalharAtendente(id int64) (*entity.AtendenteEntity, error) {
+	return buscarAtendente(as.AtendenteRepository, id)
+}
+
+// EditarAtendente troca nome, habilidades e situação. O novo nome também
+// passa a aparecer no balcão do atendente, na mesma transação.
+func (as *AtendenteService) EditarAtendente(id int64, atendenteDTO *dto.EditarAtendenteDTO) (*entity.AtendenteEntity, error) {
+	if atendenteDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O atendente não pode ser nulo."}
+	}
+
+	var atendente *entity.AtendenteEntity
+	err := as.emTransacao(func(tx *AtendenteService) error {
+		var err error
+		atendente, err = tx.editarAtendente(id, atendenteDTO)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return atendente, nil
+}
+
+func (as *AtendenteService) editarAtendente(id int64, atendenteDTO *dto.EditarAtendenteDTO) (*entity.AtendenteEntity, error) {
+	atendente, err := buscarAtendente(as.AtendenteRepository, id)
+	if err != nil {
+		return nil, err
+	}
+	if atendenteDTO.Ativo != nil && !*atendenteDTO.Ativo && atendente.Ativo {
+		if err := as.validarDesativacao(atendente); err != nil {
+			return nil, err
+		}
+	}
+
+	renomeado := atendente.Nome != atendenteDTO.Nome
+	atendente.Nome = atendenteDTO.Nome
+	atendente.Habilidades = normalizarHabilidades(atendenteDTO.Habilidades)
+	if atendenteDTO.Ativo != nil {
+		atendente.Ativo = *atendenteDTO.Ativo
+	}
+
+	atendenteSalvo, err := as.AtendenteRepository.Save(*atendente)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar atendente: %w", err)
+	}
+	if renomeado {
+		if err := as.renomearBalcao(atendenteSalvo); err != nil {
+			return nil, err
+		}
+	}
+	return &atendenteSalvo, nil
+}
+
+// DesativarAtendente impede que o atendente assuma chamados ou receba um
+// balcão. O atendente não pode estar em um balcão nem ter chamados em
+// andamento. Desativar um atendente já inativo não é erro.
+func (as *AtendenteService) DesativarAtendente(id int64) error {
+	return as.emTransacao(func(tx *AtendenteService) error {
+		return tx.desativarAtendente(id)
+	})
+}
+
+func (as *AtendenteService) desativarAtendente(id int64) error {
+	atendente, err := buscarAtendente(as.AtendenteRepository, id)
+	if err != nil {
+		return err
+	}
+	if !atendente.Ativo {
+		return nil
+	}
+	if err := as.validarDesativacao(atendente); err != nil {
+		return err
+	}
+
+	atendente.Ativo = false
+	if _, err := as.AtendenteRepository.Save(*atendente); err != nil {
+		return fmt.Errorf("Erro ao salvar atendente: %w", err)
+	}
+	return nil
+}
+
+func (as *AtendenteService) validarDesativacao(atendente *entity.AtendenteEntity) error {
+	if err := as.validarSemChamadoEmAndamento(atendente); err != nil {
+		return err
+	}
+	balcao, err := as.BalcaoRepository.FindByAtendente(atendente.ID)
+	if err != nil {
+		return fmt.Errorf("Erro ao buscar balcão do atendente %d: %w", atendente.ID, err)
+	}
+	if balcao != nil {
+		return &Exception.ConflictException{
+			Message: fmt.Sprintf("O atendente %s ainda está no balcão %d. Passe o balcão para outro atendente antes de desativá-lo.", atendente.Login, balcao.ID),
+			Uri:     fmt.Sprintf("/api/v1/balcoes/%d", balcao.ID),
+			Codigo:  Exception.CodigoAtendenteComBalcao,
+		}
+	}
+	return nil
+}
+
+func (as *AtendenteService) validarSemChamadoEmAndamento(atendente *entity.AtendenteEntity) error {
+	emAndamento, err := as.ChamadoRepository.FindByAtendenteAndEstado(atendente.ID, model.EmAndamento)
+	if err != nil {
+		return fmt.Errorf("Erro ao buscar chamados do atendente %d: %w", atendente.ID, err)
+	}
+	if len(emAndamento) > 0 {
+		return &Exception.ConflictException{
+			Message: fmt.Sprintf("O atendente %s ainda tem %d chamado(s) em andamento.", atendente.Login, len(emAndamento)),
+			Uri:     fmt.Sprintf("/api/v1/chamados/%d", emAndamento[0].ID),
+			Codigo:  Exception.CodigoAtendenteOcupado,
+		}
+	}
+	return nil
+}
+
+func (as *AtendenteService) renomearBalcao(atendente entity.AtendenteEntity) error {
+	balcao, err := as.BalcaoRepository.FindByAtendente(atendente.ID)
+	if err != nil {
+		return fmt.Errorf("Erro ao buscar balcão do atendente %d: %w", atendente.ID, err)
+	}
+	if balcao == nil {
+		return nil
+	}
+	balcao.NomeAtendente = atendente.Nome
+	if _, err := as.BalcaoRepository.Save(*balcao); err != nil {
+		return fmt.Errorf("Erro ao salvar balcão: %w", erroDeVersao("Balcão", balcao.ID, err))
+	}
+	return nil
+}
+
+func buscarAtendente(repo repository.AtendenteRepository, id int64) (*entity.AtendenteEntity, error) {
+	atendente, err := repo.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar atendente com ID %d: %w", id, err)
+	}
+	if atendente == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Atendente", ID: id}
+	}
+	return atendente, nil
+}
+
+// buscarAtendenteAtivo é usado por quem vincula o atendente a um balcão ou
+// chamado: atendentes inativos não podem receber trabalho novo.
+func buscarAtendenteAtivo(repo repository.AtendenteRepository, id int64) (*entity.AtendenteEntity, error) {
+	atendente, err := buscarAtendente(repo, id)
+	if err != nil {
+		return nil, err
+	}
+	if !atendente.Ativo {
+		return nil, &Exception.ConflictException{
+			Message: fmt.Sprintf("O atendente %s está inativo.", atendente.Login),
+			Uri:     fmt.Sprintf("/api/v1/atendentes/%d", atendente.ID),
+			Codigo:  Exception.CodigoAtendenteInativo,
+		}
+	}
+	return atendente, nil
+}
+
+func loginDuplicado(login string) error {
+	return &Exception.ConflictException{
+		Message: "Já existe um atendente com o login " + login + ".",
+		Uri:     "/api/v1/atendentes",
+		Codigo:  Exception.CodigoAtendenteDuplicado,
+	}
+}
+
+// normalizarHabilidades remove espaços e repetições, sem diferenciar
+// maiúsculas, mantendo a ordem informada.
+func normalizarHabilidades(habilidades []string) []string {
+	normalizadas := make([]string, 0, len(habilidades))
+	vistas := make(map[string]bool, len(habilidades))
+	for _, habilidade := range habilidades {
+		habilidade = strings.TrimSpace(habilidade)
+		chave := strings.ToLower(habilidade)
+		if habilidade == "" || vistas[chave] {
+			continue
+		}
+		vistas[chave] = true
+		normalizadas = append(normalizadas, habilidade)
+	}
+	return normalizadas
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"fmt"
+	"helpdesk/Exception"
 	"helpdesk/dto"
 	"helpdesk/entity"
 	"helpdesk/model"
@@ -10,42 +11,126 @@ import (
 )
 
 type BalcaoService struct {
-	BalcaoRepository repository.BalcaoRepository
+	BalcaoRepository    repository.BalcaoRepository
+	ChamadoRepository   repository.ChamadoRepository
+	AtendenteRepository repository.AtendenteRepository
+	CapacidadePadrao    int
+	unidade             repository.UnidadeDeTrabalho
 }
 
-func (cs *BalcaoService) CadastrarBalcao(balcaoDTO *dto.BalcaoDTO) (*entity.BalcaoEntity, error) {
+// NovoBalcaoService monta o serviço sobre repositórios avulsos, sem
+// transação entre eles. Em produção use NovoBalcaoServiceTransacional.
+func NovoBalcaoService(balcaoRepo repository.BalcaoRepository, chamadoRepo repository.ChamadoRepository, atendenteRepo repository.AtendenteRepository) *BalcaoService {
+	return NovoBalcaoServiceTransacional(semTransacao{repository.Repositorios{
+		Balcoes:    balcaoRepo,
+		Chamados:   chamadoRepo,
+		Atendentes: atendenteRepo,
+	}})
+}
+
+func NovoBalcaoServiceTransacional(unidade repository.UnidadeDeTrabalho) *BalcaoService {
+	repos := unidade.Repositorios()
+	return &BalcaoService{
+		BalcaoRepository:    repos.Balcoes,
+		ChamadoRepository:   repos.Chamados,
+		AtendenteRepository: repos.Atendentes,
+		CapacidadePadrao:    limiteAtendimentosPadrao,
+		unidade:             unidade,
+	}
+}
+
+// balcaoEmTransacao executa fn com uma cópia do serviço cujos repositórios
+// participam da mesma transação, de modo que as verificações feitas em fn
+// valem até o Save. Serviços montados sem unidade de trabalho usam os
+// próprios repositórios.
+func (bs *BalcaoService) balcaoEmTransacao(fn func(tx *BalcaoService) (*entity.BalcaoEntity, error)) (*entity.BalcaoEntity, error) {
+	if bs.unidade == nil {
+		return fn(bs)
+	}
+	var balcao *entity.BalcaoEntity
+	err := bs.unidade.Executar(func(repos repository.Repositorios) error {
+		tx := *bs
+		tx.BalcaoRepository = repos.Balcoes
+		tx.ChamadoRepository = repos.Chamados
+		tx.AtendenteRepository = repos.Atendentes
+		tx.unidade = semTransacao{repos}
+
+		var err error
+		balcao, err = fn(&tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return balcao, nil
+}
+
+func (cs *BalcaoService) CadastrarBalcao(balcaoDTO *dto.CriarBalcaoDTO) (*entity.BalcaoEntity, error) {
 	if balcaoDTO == nil {
-		return nil, errors.New("O balcão nao pode ser nulo!")
+		return nil, &Exception.ValidationException{Message: "O balcão nao pode ser nulo!"}
+	}
+	if balcaoDTO.Capacidade < 0 {
+		return nil, capacidadeNegativa()
+	}
+	return cs.balcaoEmTransacao(func(tx *BalcaoService) (*entity.BalcaoEntity, error) {
+		return tx.cadastrarBalcao(balcaoDTO)
+	})
+}
+
+func (cs *BalcaoService) cadastrarBalcao(balcaoDTO *dto.CriarBalcaoDTO) (*entity.BalcaoEntity, error) {
+	atendente, err := cs.atendenteSemBalcao(balcaoDTO.IDAtendente, 0)
+	if err != nil {
+		return nil, err
 	}
-	if cs.AtentendePossuiBalcao(balcaoDTO.NomeAtendente) {
-		return nil, errors.New("O atendente " + balcaoDTO.NomeAtendente + " já possui um balcão.")
+
+	capacidade := balcaoDTO.Capacidade
+	if capacidade == 0 {
+		capacidade = cs.capacidadePadrao()
 	}
 
 	balcao := entity.BalcaoEntity{
 		Balcao: model.Balcao{
-			NomeAtendente:   balcaoDTO.NomeAtendente,
-			FilaAtendimento: balcaoDTO.FilaAtendimento,
+			IDAtendente:   atendente.ID,
+			NomeAtendente: atendente.Nome,
+			Capacidade:    capacidade,
+			Ativo:         true,
 		},
 	}
 
 	saveBalcao, err := cs.BalcaoRepository.Save(balcao)
+	if errors.Is(err, repository.ErrRegistroDuplicado) {
+		return nil, atendenteComBalcao(atendente)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &saveBalcao, nil
 }
 
-func (bs *BalcaoService) AtentendePossuiBalcao(nomeAtendente string) bool {
-	balcoes, err := bs.BalcaoRepository.FindAll()
+// atendenteSemBalcao busca o atendente ativo que vai operar o balcão
+// idBalcao (zero para um balcão novo). Cada atendente opera um balcão só;
+// o repositório garante isso, e a consulta aqui apenas antecipa o erro.
+func (bs *BalcaoService) atendenteSemBalcao(idAtendente, idBalcao int64) (*entity.AtendenteEntity, error) {
+	atendente, err := buscarAtendenteAtivo(bs.AtendenteRepository, idAtendente)
 	if err != nil {
-		return false
+		return nil, err
 	}
-	for _, balcao := range balcoes {
-		if balcao.NomeAtendente == nomeAtendente {
-			return true
-		}
+	balcao, err := bs.BalcaoRepository.FindByAtendente(atendente.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar balcão do atendente %d: %w", atendente.ID, err)
+	}
+	if balcao != nil && balcao.ID != idBalcao {
+		return nil, atendenteComBalcao(atendente)
+	}
+	return atendente, nil
+}
+
+func atendenteComBalcao(atendente *entity.AtendenteEntity) error {
+	return &Exception.ConflictException{
+		Message: "O atendente " + atendente.Login + " já possui um balcão.",
+		Uri:     "/api/v1/balcoes",
+		Codigo:  Exception.CodigoBalcaoDuplicado,
 	}
-	return false
 }
 
 func (bs *BalcaoService) ListarBalcoes() ([]entity.BalcaoEntity, error) {
@@ -56,36 +141,197 @@ func (bs *BalcaoService) ListarBalcoes() ([]entity.BalcaoEntity, error) {
 	return balcoes, nil
 }
 
-func (bs *BalcaoService) EditarBalcao(balcaoDTO *dto.BalcaoDTO, id int64) (*dto.BalcaoDTO, error) {
+func (bs *BalcaoService) DetalharBalcao(id int64) (*entity.BalcaoEntity, model.OcupacaoBalcao, error) {
+	balcao, err := bs.buscarBalcao(id)
+	if err != nil {
+		return nil, model.OcupacaoBalcao{}, err
+	}
+	ocupacao, err := bs.ocupacao(balcao)
+	if err != nil {
+		return nil, model.OcupacaoBalcao{}, err
+	}
+	return balcao, ocupacao, nil
+}
+
+// alteracaoBalcao reúne o que PUT e PATCH podem mudar. Campos nil e
+// capacidade zero mantêm o valor atual.
+type alteracaoBalcao struct {
+	IDAtendente *int64
+	Capacidade  int
+	Ativo       *bool
+}
+
+func (bs *BalcaoService) EditarBalcao(balcaoDTO *dto.EditarBalcaoDTO, id int64) (*entity.BalcaoEntity, error) {
 	if balcaoDTO == nil {
-		return nil, errors.New("Balcão ou ID não podem ser nulos")
+		return nil, &Exception.ValidationException{Message: "Balcão ou ID não podem ser nulos"}
+	}
+	if balcaoDTO.Capacidade < 0 {
+		return nil, capacidadeNegativa()
+	}
+	return bs.balcaoEmTransacao(func(tx *BalcaoService) (*entity.BalcaoEntity, error) {
+		return tx.alterarBalcao(id, balcaoDTO.Versao, alteracaoBalcao{
+			IDAtendente: &balcaoDTO.IDAtendente,
+			Capacidade:  balcaoDTO.Capacidade,
+			Ativo:       balcaoDTO.Ativo,
+		})
+	})
+}
+
+func (bs *BalcaoService) AtualizarBalcaoParcial(id int64, patch *dto.PatchBalcaoDTO) (*entity.BalcaoEntity, error) {
+	if patch == nil {
+		return nil, &Exception.ValidationException{Message: "Balcão ou ID não podem ser nulos"}
 	}
+	alteracao := alteracaoBalcao{IDAtendente: patch.IDAtendente, Ativo: patch.Ativo}
+	if patch.Capacidade != nil {
+		if *patch.Capacidade < 0 {
+			return nil, capacidadeNegativa()
+		}
+		alteracao.Capacidade = *patch.Capacidade
+	}
+	return bs.balcaoEmTransacao(func(tx *BalcaoService) (*entity.BalcaoEntity, error) {
+		return tx.alterarBalcao(id, patch.Versao, alteracao)
+	})
+}
+
+// DesativarBalcao impede que o balcão receba novos chamados. Desativar um
+// balcão já inativo não é erro.
+func (bs *BalcaoService) DesativarBalcao(id int64) error {
+	desativar := false
+	_, err := bs.balcaoEmTransacao(func(tx *BalcaoService) (*entity.BalcaoEntity, error) {
+		return tx.alterarBalcao(id, 0, alteracaoBalcao{Ativo: &desativar})
+	})
+	return err
+}
+
+func (bs *BalcaoService) alterarBalcao(id, versao int64, alteracao alteracaoBalcao) (*entity.BalcaoEntity, error) {
+	buscar := bs.buscarBalcao
+	if alteracao.Ativo != nil && !*alteracao.Ativo {
+		// A fila só pode ser conferida com o balcão travado: uma reserva
+		// concorrente espera a desativação e então falha por ele estar inativo.
+		buscar = bs.travarBalcao
+	}
+	balcaoExistente, err := buscar(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := conferirVersao("Balcão", id, versao, balcaoExistente.Versao); err != nil {
+		return nil, err
+	}
+
+	if alteracao.Capacidade > 0 && alteracao.Capacidade != balcaoExistente.Capacidade {
+		if err := validarCapacidade(balcaoExistente, alteracao.Capacidade); err != nil {
+			return nil, err
+		}
+		balcaoExistente.Capacidade = alteracao.Capacidade
+	}
+	var atendente *entity.AtendenteEntity
+	if alteracao.IDAtendente != nil && *alteracao.IDAtendente != balcaoExistente.IDAtendente {
+		atendente, err = bs.atendenteSemBalcao(*alteracao.IDAtendente, balcaoExistente.ID)
+		if err != nil {
+			return nil, err
+		}
+		balcaoExistente.IDAtendente = atendente.ID
+		balcaoExistente.NomeAtendente = atendente.Nome
+	}
+	if alteracao.Ativo != nil && *alteracao.Ativo != balcaoExistente.Ativo {
+		if !*alteracao.Ativo {
+			if err := bs.validarFilaVazia(balcaoExistente); err != nil {
+				return nil, err
+			}
+		}
+		balcaoExistente.Ativo = *alteracao.Ativo
+	}
+
+	balcaoSalvo, err := bs.BalcaoRepository.Save(*balcaoExistente)
+	if errors.Is(err, repository.ErrRegistroDuplicado) && atendente != nil {
+		return nil, atendenteComBalcao(atendente)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar balcão: %w", erroDeVersao("Balcão", id, err))
+	}
+	return &balcaoSalvo, nil
+}
+
+func (bs *BalcaoService) buscarBalcao(id int64) (*entity.BalcaoEntity, error) {
+	balcao, err := bs.BalcaoRepository.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar balcão com ID %d: %w", id, err)
+	}
+	if balcao == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Balcão", ID: id}
+	}
+	return balcao, nil
+}
 
-	if balcaoDTO.ID != id {
-		return nil, errors.New("O ID do Balcão no DTO não corresponde ao ID fornecido.")
+func (bs *BalcaoService) travarBalcao(id int64) (*entity.BalcaoEntity, error) {
+	balcao, err := bs.BalcaoRepository.FindByIdForUpdate(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar balcão com ID %d: %w", id, err)
 	}
+	if balcao == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Balcão", ID: id}
+	}
+	return balcao, nil
+}
 
-	balcaoExistente, err := bs.BalcaoRepository.FindById(id)
+func (bs *BalcaoService) ocupacao(balcao *entity.BalcaoEntity) (model.OcupacaoBalcao, error) {
+	aguardando, err := bs.ChamadoRepository.ContarChamados(model.FiltroChamados{
+		IDBalcao: balcao.ID,
+		Status:   []model.StatusChamado{model.Aguardando},
+	})
 	if err != nil {
-		return nil, &NotFoundError{ID: int(id)}
+		return model.OcupacaoBalcao{}, fmt.Errorf("Erro ao contar chamados aguardando no balcão %d: %w", balcao.ID, err)
 	}
 
-	balcaoExistente.NomeAtendente = balcaoDTO.NomeAtendente
-	balcaoExistente.FilaAtendimento = balcaoDTO.FilaAtendimento
+	capacidade := balcao.Capacidade
+	if capacidade <= 0 {
+		capacidade = bs.capacidadePadrao()
+	}
+	return model.OcupacaoBalcao{
+		EmAtendimento: balcao.FilaAtendimento,
+		Capacidade:    capacidade,
+		VagasLivres:   max(capacidade-balcao.FilaAtendimento, 0),
+		Aguardando:    aguardando,
+	}, nil
+}
 
-	_, err = bs.BalcaoRepository.Save(*balcaoExistente)
+// validarFilaVazia impede desativar um balcão com chamados em atendimento
+// ou aguardando vaga.
+func (bs *BalcaoService) validarFilaVazia(balcao *entity.BalcaoEntity) error {
+	ocupacao, err := bs.ocupacao(balcao)
 	if err != nil {
-		return nil, fmt.Errorf("Erro ao salvar balcão: %w", err)
+		return err
+	}
+	if ocupacao.EmAtendimento > 0 || ocupacao.Aguardando > 0 {
+		return &Exception.ConflictException{
+			Message: fmt.Sprintf("O balcão %d ainda tem %d chamado(s) em atendimento e %d aguardando.",
+				balcao.ID, ocupacao.EmAtendimento, ocupacao.Aguardando),
+			Uri:    fmt.Sprintf("/api/v1/balcoes/%d", balcao.ID),
+			Codigo: Exception.CodigoBalcaoComFila,
+		}
 	}
+	return nil
+}
 
-	balcaoDTO.ID = balcaoExistente.ID
-	return balcaoDTO, nil
+// validarCapacidade compara a nova capacidade com a fila do balcão, o mesmo
+// contador que ReservarVaga usa.
+func validarCapacidade(balcao *entity.BalcaoEntity, capacidade int) error {
+	if capacidade < balcao.FilaAtendimento {
+		return &Exception.CapacidadeExcedidaException{BalcaoID: balcao.ID, Capacidade: capacidade, Abertos: int64(balcao.FilaAtendimento)}
+	}
+	return nil
 }
 
-type NotFoundError struct {
-	ID int
+func (bs *BalcaoService) capacidadePadrao() int {
+	if bs.CapacidadePadrao > 0 {
+		return bs.CapacidadePadrao
+	}
+	return limiteAtendimentosPadrao
 }
 
-func (e *NotFoundError) Error() string {
-	return fmt.Sprintf("O recurso com ID %d não foi encontrado", e.ID)
+func capacidadeNegativa() error {
+	return &Exception.ValidationException{
+		Message: "A capacidade do balcão não pode ser negativa.",
+		Campos:  map[string]string{"capacidade": "deve ser maior ou igual a zero"},
+	}
 }
@@ -1,8 +1,6 @@
 package service
 
-import "C"
 import (
-	"errors"
 	"fmt"
 	"helpdesk/Exception"
 	"helpdesk/dto"
@@ -10,140 +8,303 @@ import (
 	"helpdesk/model"
 	"helpdesk/repository"
 	"helpdesk/utils"
+	"log/slog"
 	"time"
 )
 
+const limiteAtendimentosPadrao = 5
+
 type ChamadoService struct {
 	chamadoRepository     repository.ChamadoRepository
 	balcaoRepository      repository.BalcaoRepository
 	atendimentoRepository repository.AtendimentoRepository
+	atendenteRepository   repository.AtendenteRepository
+	clienteRepository     repository.ClienteRepository
+	dispositivoRepository repository.DispositivoRepository
+	produtoRepository     repository.ProdutoRepository
+	unidade               repository.UnidadeDeTrabalho
+	LimiteAtendimentos    int
+	// Garantia é consultada na abertura do chamado e RegrasGarantia, aplicadas
+	// em ordem, podem trocar o balcão conforme o resultado.
+	Garantia       ProvedorGarantia
+	RegrasGarantia []RegraGarantia
 }
 
-type AtendimentoService struct {
-	atendimentoRepository repository.AtendimentoRepository
-	balcaoRepository      repository.BalcaoRepository
+// NovoChamadoService monta o serviço sobre repositórios avulsos, sem
+// transação entre eles. Em produção use NovoChamadoServiceTransacional.
+func NovoChamadoService(chamadoRepo repository.ChamadoRepository, balcaoRepo repository.BalcaoRepository, atendimentoRepo repository.AtendimentoRepository, atendenteRepo repository.AtendenteRepository, clienteRepo repository.ClienteRepository, dispositivoRepo repository.DispositivoRepository, produtoRepo repository.ProdutoRepository) *ChamadoService {
+	return NovoChamadoServiceTransacional(semTransacao{repository.Repositorios{
+		Chamados:     chamadoRepo,
+		Balcoes:      balcaoRepo,
+		Atendimentos: atendimentoRepo,
+		Atendentes:   atendenteRepo,
+		Clientes:     clienteRepo,
+		Dispositivos: dispositivoRepo,
+		Produtos:     produtoRepo,
+	}})
 }
 
-type Chamado struct {
-	chamado entity.ChamadoEntity
+func NovoChamadoServiceTransacional(unidade repository.UnidadeDeTrabalho) *ChamadoService {
+	repos := unidade.Repositorios()
+	return &ChamadoService{
+		chamadoRepository:     repos.Chamados,
+		balcaoRepository:      repos.Balcoes,
+		atendimentoRepository: repos.Atendimentos,
+		atendenteRepository:   repos.Atendentes,
+		clienteRepository:     repos.Clientes,
+		dispositivoRepository: repos.Dispositivos,
+		produtoRepository:     repos.Produtos,
+		unidade:               unidade,
+		LimiteAtendimentos:    limiteAtendimentosPadrao,
+		Garantia:              &GarantiaPorDataCompra{Dispositivos: repos.Dispositivos, Meses: mesesGarantiaPadrao},
+	}
 }
 
-func NovoAtendimentoService(atendimentoRepo repository.AtendimentoRepository, balcaoRepo repository.BalcaoRepository) *AtendimentoService {
-	return &AtendimentoService{
-		atendimentoRepository: atendimentoRepo,
-		balcaoRepository:      balcaoRepo,
-	}
+// emTransacao executa fn com uma cópia do serviço cujos repositórios
+// participam da mesma transação.
+func (cs *ChamadoService) emTransacao(fn func(tx *ChamadoService) error) error {
+	return cs.unidade.Executar(func(repos repository.Repositorios) error {
+		tx := *cs
+		tx.chamadoRepository = repos.Chamados
+		tx.balcaoRepository = repos.Balcoes
+		tx.atendimentoRepository = repos.Atendimentos
+		tx.atendenteRepository = repos.Atendentes
+		tx.clienteRepository = repos.Clientes
+		tx.dispositivoRepository = repos.Dispositivos
+		tx.produtoRepository = repos.Produtos
+		tx.unidade = semTransacao{repos}
+		return fn(&tx)
+	})
 }
 
-func NovoChamadoService(chamadoRepo repository.ChamadoRepository, balcaoRepo repository.BalcaoRepository) *ChamadoService {
-	return &ChamadoService{
-		chamadoRepository: chamadoRepo,
-		balcaoRepository:  balcaoRepo,
-	}
+// semTransacao apenas repassa os repositórios, sem desfazer nada em caso de
+// erro.
+type semTransacao struct {
+	repos repository.Repositorios
 }
 
-func (cs *ChamadoService) CriarChamado(chamadosDTO *dto.ChamadoDTO) (*entity.ChamadoEntity, error) {
-	if chamadosDTO == nil {
-		return nil, errors.New("Chamado não pode ser nulo.")
-	}
+func (s semTransacao) Repositorios() repository.Repositorios {
+	return s.repos
+}
+
+func (s semTransacao) Executar(fn func(repos repository.Repositorios) error) error {
+	return fn(s.repos)
+}
 
-	if _, err := cs.PegarChamado(chamadosDTO); err != nil {
+// chamadoEmTransacao executa uma operação que altera chamados, balcões e a
+// lista de atendimento como uma unidade: qualquer erro desfaz tudo.
+func (cs *ChamadoService) chamadoEmTransacao(fn func(tx *ChamadoService) (*entity.ChamadoEntity, error)) (*entity.ChamadoEntity, error) {
+	var chamado *entity.ChamadoEntity
+	err := cs.emTransacao(func(tx *ChamadoService) error {
+		var err error
+		chamado, err = fn(tx)
+		return err
+	})
+	if err != nil {
 		return nil, err
 	}
+	return chamado, nil
+}
 
-	balcao, err := cs.balcaoRepository.FindById(chamadosDTO.IDBalcao)
-	if err != nil || balcao == nil {
-		return nil, errors.New("Balcão não encontrado.")
+func (cs *ChamadoService) CriarChamado(chamadosDTO *dto.CriarChamadoDTO) (*entity.ChamadoEntity, error) {
+	return cs.chamadoEmTransacao(func(tx *ChamadoService) (*entity.ChamadoEntity, error) {
+		return tx.criarChamado(chamadosDTO)
+	})
+}
+
+func (cs *ChamadoService) criarChamado(chamadosDTO *dto.CriarChamadoDTO) (*entity.ChamadoEntity, error) {
+	if chamadosDTO == nil {
+		return nil, &Exception.ValidationException{Message: "Chamado não pode ser nulo."}
 	}
 
-	podeAtender, err := cs.BalcaoPodeAtender(balcao)
+	cliente, err := buscarCliente(cs.clienteRepository, chamadosDTO.CustomerID)
 	if err != nil {
 		return nil, err
 	}
-	if !podeAtender {
-		return nil, errors.New("Balcão cheio. O chamado será colocado na fila de espera.")
-	}
 
 	chamadoExistente, err := cs.chamadoRepository.FindBySerial(chamadosDTO.SerialNumber)
 	if err != nil {
 		return nil, err
 	}
 
-	if chamadoExistente == nil {
-		return nil, errors.New("Nenhum chamado encontrado para o serial fornecido.")
+	if chamadoExistente != nil {
+		if chamadoExistente.CustomerID == chamadosDTO.CustomerID {
+			if chamadoExistente.StatusChamado.Ativo() {
+				return nil, &Exception.ConflictException{
+					Message: "Já existe um chamado aberto para este serial.",
+					Uri:     fmt.Sprintf("/api/v1/chamados/%d", chamadoExistente.ID),
+					Codigo:  Exception.CodigoSerialEmUso,
+				}
+			}
+		} else {
+			if chamadoExistente.StatusChamado.Ativo() {
+				return nil, &Exception.ForbiddenException{
+					Message: "Este serial já está em atendimento por outro usuário.",
+					Uri:     fmt.Sprintf("/api/v1/chamados/%d", chamadoExistente.ID),
+					Codigo:  Exception.CodigoSerialEmUso,
+				}
+			}
+		}
 	}
 
-	if chamadoExistente.CustomerID == chamadosDTO.CustomerID {
-		if chamadoExistente.StatusChamado != "ABERTO" {
-			return nil, &Exception.ConflictException{
-				Message: "Já existe um chamado aberto para este serial.",
-				Uri:     fmt.Sprintf("/api/chamados/%d", chamadoExistente.ID),
-			}
+	var produto *entity.ProdutoEntity
+	if chamadosDTO.IDProduto != 0 {
+		produto, err = buscarProduto(cs.produtoRepository, chamadosDTO.IDProduto)
+		if err != nil {
+			return nil, err
 		}
-	} else {
-		if chamadoExistente.StatusChamado != "RESOLVIDO" {
-			return nil, &Exception.ForbiddenException{
-				Message: "Este serial já está em atendimento por outro usuário.",
-				Uri:     fmt.Sprintf("/api/chamados/%d", chamadoExistente.ID),
-			}
+	}
+	idBalcao := chamadosDTO.IDBalcao
+	if idBalcao == 0 && produto != nil {
+		idBalcao = produto.IDBalcaoPadrao
+	}
+	if idBalcao == 0 {
+		return nil, &Exception.ValidationException{
+			Message: "Dados inválidos.",
+			Campos:  map[string]string{"id_balcao": "obrigatório: o produto não tem balcão padrão"},
 		}
 	}
 
+	garantia := cs.consultarGarantia(chamadosDTO.SerialNumber)
+	balcao, err := cs.buscarBalcao(cs.balcaoPelaGarantia(garantia, idBalcao))
+	if err != nil {
+		return nil, err
+	}
+	if !balcao.Ativo {
+		return nil, &Exception.ConflictException{
+			Message: fmt.Sprintf("O balcão %d está inativo e não recebe novos chamados.", balcao.ID),
+			Uri:     fmt.Sprintf("/api/v1/balcoes/%d", balcao.ID),
+			Codigo:  Exception.CodigoBalcaoInativo,
+		}
+	}
+
+	reservou, err := cs.ReservarVaga(balcao)
+	if err != nil {
+		return nil, err
+	}
+
 	novoChamado := ConvertDTOToEntity(chamadosDTO)
 
 	novoChamado.DataCreation = time.Now()
-	novoChamado.DataResolution = time.Time{}
-	novoChamado.StatusChamado = string(entity.Aberto)
+	novoChamado.IDBalcao = balcao.ID
+	novoChamado.Garantia = garantia
+	if produto != nil && novoChamado.Produto == "" {
+		novoChamado.Produto = produto.Nome
+	}
+	if novoChamado.UserClient == "" {
+		novoChamado.UserClient = cliente.Nome
+	}
+	if err := cs.registrarDispositivo(novoChamado); err != nil {
+		return nil, err
+	}
+	novoChamado.StatusChamado = model.Aberto
+	if !reservou {
+		novoChamado.StatusChamado = model.Aguardando
+	}
 	novoChamado.Balcao = utils.ConvertBalcaoEntityToBalcao(balcao)
-	novoChamado.DeviceID = chamadosDTO.DeviceID
-	novoChamado.Motivo = chamadosDTO.Motivo
-	novoChamado.UserClient = chamadosDTO.UserClient
-	novoChamado.IDBalcao = chamadosDTO.IDBalcao
 
 	chamadoSalvo, err := cs.chamadoRepository.Save(novoChamado)
 	if err != nil {
-		return nil, fmt.Errorf("Erro ao salvar o chamado: %v", err)
+		return nil, fmt.Errorf("Erro ao salvar o chamado: %w", err)
+	}
+
+	if !reservou {
+		if err := cs.preencherPosicaoFila(chamadoSalvo); err != nil {
+			return nil, err
+		}
+		return chamadoSalvo, nil
 	}
 
 	if err := cs.AcrescentarFilaAtendimento(balcao, chamadoSalvo); err != nil {
-		return nil, fmt.Errorf("Erro ao adicionar o chamado na fila de atendimento: %v", err)
+		return nil, fmt.Errorf("Erro ao adicionar o chamado na fila de atendimento: %w", err)
 	}
 
 	return chamadoSalvo, nil
 }
 
-func (cs *ChamadoService) PegarChamado(chamadoDTO *dto.ChamadoDTO) (*dto.ChamadoDTO, error) {
-	if chamadoDTO == nil {
-		return nil, errors.New("chamado nao pode ser nulo!")
+// consultarGarantia não impede a abertura do chamado: se o provedor falhar,
+// a garantia fica desconhecida.
+func (cs *ChamadoService) consultarGarantia(serial string) model.StatusGarantia {
+	if cs.Garantia == nil {
+		return model.GarantiaDesconhecida
+	}
+	garantia, err := cs.Garantia.ConsultarGarantia(serial)
+	if err != nil {
+		slog.Warn("erro ao consultar garantia", "serial", serial, "erro", err)
+		return model.GarantiaDesconhecida
+	}
+	return garantia
+}
+
+func (cs *ChamadoService) balcaoPelaGarantia(garantia model.StatusGarantia, idBalcao int64) int64 {
+	for _, regra := range cs.RegrasGarantia {
+		idBalcao = regra(garantia, idBalcao)
 	}
+	return idBalcao
+}
 
-	chamadoExistente, err := cs.chamadoRepository.FindByUsuarioAtendenteAndEstado(chamadoDTO.UserAtendente, entity.StatusChamado(entity.Aberto))
+// registrarDispositivo cadastra o serial do chamado, em nome do cliente do
+// chamado, quando ele ainda não consta no cadastro de dispositivos. Se já
+// consta e o chamado não informa o produto, usa o produto cadastrado.
+func (cs *ChamadoService) registrarDispositivo(chamado *entity.ChamadoEntity) error {
+	dispositivo, err := cs.dispositivoRepository.FindBySerial(chamado.SerialNumber)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("Erro ao buscar dispositivo %q: %w", chamado.SerialNumber, err)
 	}
-	if len(chamadoExistente) > 0 {
-		return nil, errors.New("O atendente já possui um chamado ativo.")
+	if dispositivo != nil {
+		if chamado.Produto == "" {
+			chamado.Produto = dispositivo.Produto
+		}
+		return nil
 	}
-	return chamadoDTO, nil
+
+	_, err = cs.dispositivoRepository.Save(entity.DispositivoEntity{
+		Dispositivo: model.Dispositivo{
+			SerialNumber: chamado.SerialNumber,
+			Produto:      chamado.Produto,
+			CustomerID:   chamado.CustomerID,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Erro ao cadastrar dispositivo %q: %w", chamado.SerialNumber, err)
+	}
+	return nil
 }
 
-func (cs *ChamadoService) BalcaoPodeAtender(balcao *entity.BalcaoEntity) (bool, error) {
+func (cs *ChamadoService) buscarBalcao(id int64) (*entity.BalcaoEntity, error) {
+	balcao, err := cs.balcaoRepository.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar balcão com ID %d: %w", id, err)
+	}
 	if balcao == nil {
-		return false, errors.New("Balcão não encontrado.")
+		return nil, &Exception.NotFoundException{Recurso: "Balcão", ID: id}
 	}
+	return balcao, nil
+}
 
-	const limiteAtendimentos = 5
+// ReservarVaga ocupa uma vaga do balcão de forma atômica no repositório. Só
+// quem conseguiu a reserva pode abrir o chamado; os demais vão para a fila de
+// espera.
+func (cs *ChamadoService) ReservarVaga(balcao *entity.BalcaoEntity) (bool, error) {
+	if balcao == nil {
+		return false, &Exception.ValidationException{Message: "Balcão não pode ser nulo."}
+	}
 
-	qtdAbertos, err := cs.atendimentoRepository.FindOpenByBalcao(balcao.ID)
+	reservou, err := cs.balcaoRepository.ReservarVaga(balcao.ID, cs.capacidade(balcao))
 	if err != nil {
-		return false, fmt.Errorf("erro ao buscar atendimentos abertos: %w", err)
+		return false, fmt.Errorf("erro ao reservar vaga no balcão %d: %w", balcao.ID, err)
 	}
-
-	if qtdAbertos < limiteAtendimentos {
-		return true, nil
+	if reservou {
+		balcao.FilaAtendimento++
 	}
+	return reservou, nil
+}
 
-	return false, nil
+func (cs *ChamadoService) capacidade(balcao *entity.BalcaoEntity) int {
+	if balcao.Capacidade > 0 {
+		return balcao.Capacidade
+	}
+	return cs.LimiteAtendimentos
 }
 
 func (cs *ChamadoService) AcrescentarFilaAtendimento(balcao *entity.BalcaoEntity, chamado *entity.ChamadoEntity) error {
@@ -156,8 +317,9 @@ func (cs *ChamadoService) AcrescentarFilaAtendimento(balcao *entity.BalcaoEntity
 	}
 
 	atendimento := &entity.ListaAtendimento{
-		Chamado: chamado,
-		Balcao:  balcao,
+		Chamado:     chamado,
+		Balcao:      balcao,
+		DataEntrada: time.Now(),
 	}
 
 	if err := cs.atendimentoRepository.Save(atendimento); err != nil {
@@ -167,20 +329,32 @@ func (cs *ChamadoService) AcrescentarFilaAtendimento(balcao *entity.BalcaoEntity
 	return nil
 }
 
-func (cs *ChamadoService) DiminuirFilaAtendimento(idBalcao int64) error {
-	balcao, err := cs.balcaoRepository.FindById(idBalcao)
+// atualizarAtendimento aplica alterar ao atendimento do chamado que ainda
+// ocupa vaga, se existir, e o grava com o status atual do chamado.
+func (cs *ChamadoService) atualizarAtendimento(chamado *entity.ChamadoEntity, alterar func(*entity.ListaAtendimento)) error {
+	atendimento, err := cs.atendimentoRepository.FindAbertoByChamado(chamado.ID)
 	if err != nil {
-		return fmt.Errorf("Balcão com ID %d não encontrado: %w", idBalcao, err)
+		return fmt.Errorf("erro ao buscar atendimento do chamado %d: %w", chamado.ID, err)
 	}
-
-	if balcao.FilaAtendimento <= 0 {
-		return fmt.Errorf("A fila de atendimento do balcão %d já está vazia ou é inválida (valor: %d).", idBalcao, balcao.FilaAtendimento)
+	if atendimento == nil {
+		return nil
 	}
 
-	balcao.FilaAtendimento -= 1
+	atendimento.Chamado = chamado
+	alterar(atendimento)
+	if err := cs.atendimentoRepository.Save(atendimento); err != nil {
+		return fmt.Errorf("erro ao salvar atendimento: %w", err)
+	}
+	return nil
+}
 
-	if _, err := cs.balcaoRepository.Save(*balcao); err != nil {
-		return fmt.Errorf("erro ao salvar balcão com ID %d: %w", idBalcao, err)
+func (cs *ChamadoService) DiminuirFilaAtendimento(idBalcao int64) error {
+	liberou, err := cs.balcaoRepository.LiberarVaga(idBalcao)
+	if err != nil {
+		return fmt.Errorf("erro ao liberar vaga do balcão %d: %w", idBalcao, err)
+	}
+	if !liberou {
+		return fmt.Errorf("A fila de atendimento do balcão %d já está vazia ou o balcão não existe.", idBalcao)
 	}
 
 	return nil
@@ -192,7 +366,10 @@ func (cs *ChamadoService) ChamadoDetalhado(id int64) (*entity.ChamadoEntity, err
 		return nil, fmt.Errorf("Erro ao buscar chamado com ID %d: %w", id, err)
 	}
 	if chamado == nil {
-		return nil, fmt.Errorf("Chamado nao encontrado com ID %d", id)
+		return nil, &Exception.NotFoundException{Recurso: "Chamado", ID: id}
+	}
+	if err := cs.preencherPosicaoFila(chamado); err != nil {
+		return nil, err
 	}
 	return chamado, nil
 }
@@ -201,81 +378,358 @@ func (cs *ChamadoService) ListarChamados(page, size int) ([]entity.ChamadoEntity
 	return cs.chamadoRepository.FindAllPaginated(page, size)
 }
 
+// ListaChamadosCustomerId devolve os chamados do cliente, do mais recente ao
+// mais antigo. Um cliente sem chamados resulta em lista vazia; só um cliente
+// inexistente é erro.
 func (cs *ChamadoService) ListaChamadosCustomerId(customerId int64) ([]entity.ChamadoEntity, error) {
+	if _, err := buscarCliente(cs.clienteRepository, customerId); err != nil {
+		return nil, err
+	}
 	chamados, err := cs.chamadoRepository.FindByCustomerId(customerId)
 	if err != nil {
-		return nil, fmt.Errorf("Erro ao buscar chamados com ID %d: %w", customerId, err)
+		return nil, fmt.Errorf("Erro ao buscar chamados do cliente %d: %w", customerId, err)
 	}
-	if len(chamados) == 0 {
-		return nil, fmt.Errorf("Nenhum chamado encontrado para o customer com ID %d", customerId)
+	if chamados == nil {
+		chamados = []entity.ChamadoEntity{}
 	}
 	return chamados, nil
 }
 
-func (cs *ChamadoService) EditarChamado(id int64, chamadoDTO *dto.ChamadoDTO) (*entity.ChamadoEntity, error) {
+func (cs *ChamadoService) EditarChamado(id int64, chamadoDTO *dto.EditarChamadoDTO) (*entity.ChamadoEntity, error) {
+	return cs.chamadoEmTransacao(func(tx *ChamadoService) (*entity.ChamadoEntity, error) {
+		return tx.editarChamado(id, chamadoDTO)
+	})
+}
+
+func (cs *ChamadoService) editarChamado(id int64, chamadoDTO *dto.EditarChamadoDTO) (*entity.ChamadoEntity, error) {
 	if chamadoDTO == nil {
-		return nil, fmt.Errorf("Chamado nao pode ser nulo!")
+		return nil, &Exception.ValidationException{Message: "Chamado nao pode ser nulo!"}
+	}
+	return cs.alterarChamado(id, chamadoDTO.Versao, chamadoDTO.StatusChamado, func(chamado *entity.ChamadoEntity) error {
+		if chamadoDTO.CustomerID != chamado.CustomerID {
+			if _, err := buscarCliente(cs.clienteRepository, chamadoDTO.CustomerID); err != nil {
+				return err
+			}
+		}
+		anterior := chamado.Chamado
+		chamado.AlterarChamado(chamadoDTO)
+		return cs.conferirAlteracao(chamado, anterior)
+	})
+}
+
+// AtualizarChamadoParcial aplica um merge patch: campos ausentes no patch
+// mantêm o valor salvo.
+func (cs *ChamadoService) AtualizarChamadoParcial(id int64, patch *dto.PatchChamadoDTO) (*entity.ChamadoEntity, error) {
+	return cs.chamadoEmTransacao(func(tx *ChamadoService) (*entity.ChamadoEntity, error) {
+		return tx.atualizarChamadoParcial(id, patch)
+	})
+}
+
+func (cs *ChamadoService) atualizarChamadoParcial(id int64, patch *dto.PatchChamadoDTO) (*entity.ChamadoEntity, error) {
+	if patch == nil {
+		return nil, &Exception.ValidationException{Message: "Chamado nao pode ser nulo!"}
+	}
+	var novoStatus model.StatusChamado
+	if patch.StatusChamado != nil {
+		novoStatus = *patch.StatusChamado
+	}
+	return cs.alterarChamado(id, patch.Versao, novoStatus, func(chamado *entity.ChamadoEntity) error {
+		anterior := chamado.Chamado
+		chamado.AplicarPatch(patch)
+		return cs.conferirAlteracao(chamado, anterior)
+	})
+}
+
+// conferirAlteracao valida o que mudou na edição: um novo produto precisa
+// existir no catálogo e um novo serial entra no cadastro de dispositivos.
+func (cs *ChamadoService) conferirAlteracao(chamado *entity.ChamadoEntity, anterior model.Chamado) error {
+	if chamado.IDProduto != 0 && chamado.IDProduto != anterior.IDProduto {
+		produto, err := buscarProduto(cs.produtoRepository, chamado.IDProduto)
+		if err != nil {
+			return err
+		}
+		if chamado.Produto == "" {
+			chamado.Produto = produto.Nome
+		}
+	}
+	if chamado.SerialNumber != anterior.SerialNumber {
+		return cs.registrarDispositivo(chamado)
 	}
+	return nil
+}
 
+// alterarChamado carrega o chamado, confere a versão esperada, aplica a
+// alteração dos campos e, se novoStatus não for vazio, valida e executa a
+// transição de status.
+func (cs *ChamadoService) alterarChamado(id, versao int64, novoStatus model.StatusChamado, alterar func(*entity.ChamadoEntity) error) (*entity.ChamadoEntity, error) {
 	chamadoExistente, err := cs.chamadoRepository.FindById(id)
 	if err != nil {
 		return nil, fmt.Errorf("Erro ao buscar chamado com ID %d: %w", id, err)
 	}
 	if chamadoExistente == nil {
-		return nil, fmt.Errorf("Chamado não encontrado com ID %d", id)
+		return nil, &Exception.NotFoundException{Recurso: "Chamado", ID: id}
+	}
+	if err := conferirVersao("Chamado", id, versao, chamadoExistente.Versao); err != nil {
+		return nil, err
+	}
+
+	statusAtual := chamadoExistente.StatusChamado
+	if novoStatus == "" {
+		novoStatus = statusAtual
+	}
+	if err := validarTransicaoNaEdicao(statusAtual, novoStatus); err != nil {
+		return nil, err
+	}
+
+	if err := alterar(chamadoExistente); err != nil {
+		return nil, err
+	}
+	chamadoExistente.StatusChamado = statusAtual
+
+	if err := cs.aplicarTransicao(chamadoExistente, novoStatus, nil); err != nil {
+		return nil, err
 	}
 
-	if chamadoExistente.StatusChamado == "ABERTO" {
-		err = cs.DiminuirFilaAtendimento(chamadoExistente.Balcao.ID)
+	return cs.salvarTransicao(chamadoExistente, statusAtual)
+}
+
+func (cs *ChamadoService) AssumirChamado(id int64, idAtendente int64) (*entity.ChamadoEntity, error) {
+	return cs.chamadoEmTransacao(func(tx *ChamadoService) (*entity.ChamadoEntity, error) {
+		return tx.assumirChamado(id, idAtendente)
+	})
+}
+
+func (cs *ChamadoService) assumirChamado(id int64, idAtendente int64) (*entity.ChamadoEntity, error) {
+	atendente, err := buscarAtendenteAtivo(cs.atendenteRepository, idAtendente)
+	if err != nil {
+		return nil, err
+	}
+	emAndamento, err := cs.chamadoRepository.FindByAtendenteAndEstado(atendente.ID, model.EmAndamento)
+	if err != nil {
+		return nil, err
+	}
+	if len(emAndamento) > 0 {
+		return nil, &Exception.ConflictException{
+			Message: "O atendente já possui um chamado em andamento.",
+			Uri:     fmt.Sprintf("/api/v1/chamados/%d", emAndamento[0].ID),
+			Codigo:  Exception.CodigoAtendenteOcupado,
+		}
+	}
+	return cs.executarAcao(id, model.EmAndamento, atendente)
+}
+
+func (cs *ChamadoService) ResolverChamado(id int64, idAtendente int64) (*entity.ChamadoEntity, error) {
+	return cs.acaoDoAtendente(id, model.Resolvido, idAtendente)
+}
+
+func (cs *ChamadoService) FecharChamado(id int64, idAtendente int64) (*entity.ChamadoEntity, error) {
+	return cs.acaoDoAtendente(id, model.Fechado, idAtendente)
+}
+
+func (cs *ChamadoService) ReabrirChamado(id int64, idAtendente int64) (*entity.ChamadoEntity, error) {
+	return cs.acaoDoAtendente(id, model.Aberto, idAtendente)
+}
+
+func (cs *ChamadoService) acaoDoAtendente(id int64, novoStatus model.StatusChamado, idAtendente int64) (*entity.ChamadoEntity, error) {
+	return cs.chamadoEmTransacao(func(tx *ChamadoService) (*entity.ChamadoEntity, error) {
+		atendente, err := buscarAtendenteAtivo(tx.atendenteRepository, idAtendente)
 		if err != nil {
-			return nil, fmt.Errorf("Erro ao diminuir fila de atendimento: %w", err)
+			return nil, err
+		}
+		return tx.executarAcao(id, novoStatus, atendente)
+	})
+}
+
+func (cs *ChamadoService) executarAcao(id int64, novoStatus model.StatusChamado, atendente *entity.AtendenteEntity) (*entity.ChamadoEntity, error) {
+	chamado, err := cs.ChamadoDetalhado(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if chamado.StatusChamado == novoStatus {
+		return nil, &Exception.TransicaoInvalidaException{De: string(chamado.StatusChamado), Para: string(novoStatus)}
+	}
+	statusAtual := chamado.StatusChamado
+	if err := validarTransicao(statusAtual, novoStatus); err != nil {
+		return nil, err
+	}
+	if err := cs.aplicarTransicao(chamado, novoStatus, atendente); err != nil {
+		return nil, err
+	}
+
+	return cs.salvarTransicao(chamado, statusAtual)
+}
+
+// salvarTransicao persiste o chamado e, se ele liberou uma vaga no balcão,
+// promove o próximo chamado da fila de espera.
+func (cs *ChamadoService) salvarTransicao(chamado *entity.ChamadoEntity, statusAnterior model.StatusChamado) (*entity.ChamadoEntity, error) {
+	chamadoSalvo, err := cs.chamadoRepository.Save(chamado)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar o chamado atualizado: %w", erroDeVersao("Chamado", chamado.ID, err))
+	}
+
+	if statusAnterior.OcupaVaga() && !chamadoSalvo.StatusChamado.OcupaVaga() {
+		if err := cs.PromoverProximoDaFila(chamadoSalvo.IDBalcao); err != nil {
+			return nil, fmt.Errorf("Erro ao promover chamado da fila de espera: %w", err)
 		}
 	}
 
-	chamadoExistente.AlterarChamado(chamadoDTO)
+	if err := cs.preencherPosicaoFila(chamadoSalvo); err != nil {
+		return nil, err
+	}
+	return chamadoSalvo, nil
+}
 
-	updatedChamado, err := cs.chamadoRepository.Save(chamadoExistente)
+func (cs *ChamadoService) PromoverProximoDaFila(idBalcao int64) error {
+	balcao, err := cs.balcaoRepository.FindById(idBalcao)
 	if err != nil {
-		return nil, fmt.Errorf("Erro ao salvar o chamado atualizado: %w", err)
+		return fmt.Errorf("Balcão com ID %d não encontrado: %w", idBalcao, err)
+	}
+	if balcao == nil {
+		return &Exception.NotFoundException{Recurso: "Balcão", ID: idBalcao}
+	}
+
+	aguardando, err := cs.chamadoRepository.FindByBalcaoAndStatus(*balcao, model.Aguardando)
+	if err != nil {
+		return err
+	}
+	if len(aguardando) == 0 {
+		return nil
 	}
 
-	return updatedChamado, nil
+	reservou, err := cs.ReservarVaga(balcao)
+	if err != nil || !reservou {
+		return err
+	}
+
+	proximo := aguardando[0]
+	proximo.StatusChamado = model.Aberto
+	chamadoPromovido, err := cs.chamadoRepository.Save(&proximo)
+	if err != nil {
+		return fmt.Errorf("Erro ao salvar o chamado promovido: %w", erroDeVersao("Chamado", proximo.ID, err))
+	}
+	return cs.AcrescentarFilaAtendimento(balcao, chamadoPromovido)
 }
 
-func ConvertDTOToEntity(dto *dto.ChamadoDTO) *entity.ChamadoEntity {
-	return &entity.ChamadoEntity{
-		Chamado: model.Chamado{
-			ID:             dto.ID,
-			CustomerID:     dto.CustomerID,
-			DataCreation:   dto.DataCreation,
-			DataResolution: dto.DataResolution,
-			DeviceID:       dto.DeviceID,
-			SerialNumber:   dto.SerialNumber,
-			StatusChamado:  dto.StatusChamado,
-			IDBalcao:       dto.IDBalcao,
-			Motivo:         dto.Motivo,
-			Produto:        dto.Produto,
-			UserClient:     dto.UserClient,
-			UserAtendente:  dto.UserAtendente,
-		},
+func (cs *ChamadoService) preencherPosicaoFila(chamado *entity.ChamadoEntity) error {
+	chamado.PosicaoFila = 0
+	if chamado.StatusChamado != model.Aguardando {
+		return nil
 	}
+
+	balcao := entity.BalcaoEntity{Balcao: model.Balcao{ID: chamado.IDBalcao}}
+	aguardando, err := cs.chamadoRepository.FindByBalcaoAndStatus(balcao, model.Aguardando)
+	if err != nil {
+		return fmt.Errorf("Erro ao consultar fila de espera: %w", err)
+	}
+	for i, c := range aguardando {
+		if c.ID == chamado.ID {
+			chamado.PosicaoFila = i + 1
+			break
+		}
+	}
+	return nil
 }
 
-func ConvertEntityToDTO(entity *entity.ChamadoEntity) *dto.ChamadoDTO {
-	return &dto.ChamadoDTO{
+func validarTransicao(atual, novo model.StatusChamado) error {
+	if novo != atual && !atual.PodeTransicionarPara(novo) {
+		return &Exception.TransicaoInvalidaException{De: string(atual), Para: string(novo)}
+	}
+	return nil
+}
+
+// validarTransicaoNaEdicao restringe as mudanças de status feitas por PUT e
+// PATCH. Só /assumir põe o chamado em andamento, porque é lá que o atendente
+// é conferido, e só a fila de espera tira um chamado de AGUARDANDO, na ordem
+// de chegada.
+func validarTransicaoNaEdicao(atual, novo model.StatusChamado) error {
+	switch {
+	case novo == atual:
+		return nil
+	case novo == model.EmAndamento:
+		return &Exception.TransicaoInvalidaException{De: string(atual), Para: string(novo),
+			Motivo: "Use POST /chamados/{id}/assumir para iniciar o atendimento"}
+	case atual == model.Aguardando:
+		return &Exception.TransicaoInvalidaException{De: string(atual), Para: string(novo),
+			Motivo: "O chamado sai da fila de espera quando o balcão libera uma vaga"}
+	}
+	return validarTransicao(atual, novo)
+}
+
+// aplicarTransicao muda o status do chamado executando os efeitos de cada
+// transição: quem assumiu, data de resolução e a vaga ocupada no balcão.
+// atendente é nil nas mudanças de status feitas pela edição do chamado.
+func (cs *ChamadoService) aplicarTransicao(chamado *entity.ChamadoEntity, novoStatus model.StatusChamado, atendente *entity.AtendenteEntity) error {
+	statusAtual := chamado.StatusChamado
+	if atendente != nil {
+		chamado.UserUltimaAcao = atendente.Login
+	}
+	if novoStatus == statusAtual {
+		return nil
+	}
+
+	chamado.StatusChamado = novoStatus
+	agora := time.Now()
+	switch novoStatus {
+	case model.EmAndamento:
+		if atendente != nil {
+			chamado.IDAtendente = atendente.ID
+			chamado.UserAtendente = atendente.Login
+		}
+		err := cs.atualizarAtendimento(chamado, func(atendimento *entity.ListaAtendimento) {
+			atendimento.DataInicio = agora
+		})
+		if err != nil {
+			return err
+		}
+	case model.Resolvido:
+		chamado.DataResolution = agora
+	case model.Aberto:
+		chamado.DataResolution = time.Time{}
+	}
+
+	if statusAtual.OcupaVaga() && !novoStatus.OcupaVaga() {
+		err := cs.atualizarAtendimento(chamado, func(atendimento *entity.ListaAtendimento) {
+			atendimento.DataFim = agora
+		})
+		if err != nil {
+			return err
+		}
+		if err := cs.DiminuirFilaAtendimento(chamado.IDBalcao); err != nil {
+			return fmt.Errorf("Erro ao diminuir fila de atendimento: %w", err)
+		}
+	} else if !statusAtual.OcupaVaga() && novoStatus.OcupaVaga() {
+		balcao, err := cs.buscarBalcao(chamado.IDBalcao)
+		if err != nil {
+			return err
+		}
+		reservou, err := cs.ReservarVaga(balcao)
+		if err != nil {
+			return err
+		}
+		if !reservou {
+			chamado.StatusChamado = model.Aguardando
+			return nil
+		}
+		if err := cs.AcrescentarFilaAtendimento(balcao, chamado); err != nil {
+			return fmt.Errorf("Erro ao adicionar o chamado na fila de atendimento: %w", err)
+		}
+	}
+	return nil
+}
+
+func ConvertDTOToEntity(dto *dto.CriarChamadoDTO) *entity.ChamadoEntity {
+	return &entity.ChamadoEntity{
 		Chamado: model.Chamado{
-			ID:             entity.ID,
-			CustomerID:     entity.CustomerID,
-			DataCreation:   entity.DataCreation,
-			DataResolution: entity.DataResolution,
-			DeviceID:       entity.DeviceID,
-			SerialNumber:   entity.SerialNumber,
-			StatusChamado:  entity.StatusChamado,
-			IDBalcao:       entity.IDBalcao,
-			Motivo:         entity.Motivo,
-			Produto:        entity.Produto,
-			UserClient:     entity.UserClient,
-			UserAtendente:  entity.UserAtendente,
+			CustomerID:   dto.CustomerID,
+			DeviceID:     dto.DeviceID,
+			SerialNumber: dto.SerialNumber,
+			Chamado:      dto.Chamado,
+			IDBalcao:     dto.IDBalcao,
+			Motivo:       dto.Motivo,
+			IDProduto:    dto.IDProduto,
+			Produto:      dto.Produto,
+			UserClient:   dto.UserClient,
 		},
 	}
 }
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+)
+
+type ClienteService struct {
+	ClienteRepository repository.ClienteRepository
+}
+
+func NovoClienteService(clienteRepo repository.ClienteRepository) *ClienteService {
+	return &ClienteService{ClienteRepository: clienteRepo}
+}
+
+func (cs *ClienteService) CadastrarCliente(clienteDTO *dto.ClienteDTO) (*entity.ClienteEntity, error) {
+	if clienteDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O cliente não pode ser nulo."}
+	}
+	return cs.salvarCliente(entity.ClienteEntity{}, clienteDTO)
+}
+
+func (cs *ClienteService) ListarClientes() ([]entity.ClienteEntity, error) {
+	return cs.ClienteRepository.FindAll()
+}
+
+func (cs *ClienteService) DetalharCliente(id int64) (*entity.ClienteEntity, error) {
+	return buscarCliente(cs.ClienteRepository, id)
+}
+
+func (cs *ClienteService) EditarCliente(id int64, clienteDTO *dto.ClienteDTO) (*entity.ClienteEntity, error) {
+	if clienteDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O cliente não pode ser nulo."}
+	}
+	cliente, err := buscarCliente(cs.ClienteRepository, id)
+	if err != nil {
+		return nil, err
+	}
+	return cs.salvarCliente(*cliente, clienteDTO)
+}
+
+// salvarCliente aplica os dados do DTO ao cliente e o grava. O documento é
+// único: a consulta antecipa o conflito e a chave do repositório o garante.
+func (cs *ClienteService) salvarCliente(cliente entity.ClienteEntity, clienteDTO *dto.ClienteDTO) (*entity.ClienteEntity, error) {
+	documento := model.NormalizarDocumento(clienteDTO.Documento)
+
+	existente, err := cs.ClienteRepository.FindByDocumento(documento)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar cliente pelo documento: %w", err)
+	}
+	if existente != nil && existente.ID != cliente.ID {
+		return nil, documentoDuplicado(existente.ID)
+	}
+
+	cliente.Nome = clienteDTO.Nome
+	cliente.Documento = documento
+	cliente.Email = clienteDTO.Email
+	cliente.Telefone = clienteDTO.Telefone
+
+	clienteSalvo, err := cs.ClienteRepository.Save(cliente)
+	if errors.Is(err, repository.ErrRegistroDuplicado) {
+		return nil, documentoDuplicado(0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar cliente: %w", err)
+	}
+	return &clienteSalvo, nil
+}
+
+func buscarCliente(repo repository.ClienteRepository, id int64) (*entity.ClienteEntity, error) {
+	cliente, err := repo.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar cliente com ID %d: %w", id, err)
+	}
+	if cliente == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Cliente", ID: id}
+	}
+	return cliente, nil
+}
+
+// documentoDuplicado aponta para o cliente que já usa o documento, quando
+// ele é conhecido.
+func documentoDuplicado(idExistente int64) error {
+	uri := "/api/v1/clientes"
+	if idExistente != 0 {
+		uri = fmt.Sprintf("/api/v1/clientes/%d", idExistente)
+	}
+	return &Exception.ConflictException{
+		Message: "Já existe um cliente com este documento.",
+		Uri:     uri,
+		Codigo:  Exception.CodigoClienteDuplicado,
+	}
+}
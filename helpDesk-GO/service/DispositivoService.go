@@ -0,0 +1,138 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"time"
+)
+
+type DispositivoService struct {
+	DispositivoRepository repository.DispositivoRepository
+	ClienteRepository     repository.ClienteRepository
+	ChamadoRepository     repository.ChamadoRepository
+}
+
+func NovoDispositivoService(dispositivoRepo repository.DispositivoRepository, clienteRepo repository.ClienteRepository, chamadoRepo repository.ChamadoRepository) *DispositivoService {
+	return &DispositivoService{
+		DispositivoRepository: dispositivoRepo,
+		ClienteRepository:     clienteRepo,
+		ChamadoRepository:     chamadoRepo,
+	}
+}
+
+func (ds *DispositivoService) CadastrarDispositivo(dispositivoDTO *dto.CriarDispositivoDTO) (*entity.DispositivoEntity, error) {
+	if dispositivoDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O dispositivo não pode ser nulo."}
+	}
+
+	existente, err := ds.DispositivoRepository.FindBySerial(dispositivoDTO.SerialNumber)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar dispositivo %q: %w", dispositivoDTO.SerialNumber, err)
+	}
+	if existente != nil {
+		return nil, serialDuplicado(existente.SerialNumber)
+	}
+
+	dispositivo := entity.DispositivoEntity{Dispositivo: model.Dispositivo{SerialNumber: dispositivoDTO.SerialNumber}}
+	return ds.salvarDispositivo(dispositivo, dispositivoDTO.Produto, dispositivoDTO.CustomerID, dispositivoDTO.DataCompra)
+}
+
+func (ds *DispositivoService) DetalharDispositivo(serial string) (*entity.DispositivoEntity, error) {
+	return buscarDispositivo(ds.DispositivoRepository, serial)
+}
+
+func (ds *DispositivoService) EditarDispositivo(serial string, dispositivoDTO *dto.EditarDispositivoDTO) (*entity.DispositivoEntity, error) {
+	if dispositivoDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O dispositivo não pode ser nulo."}
+	}
+	dispositivo, err := buscarDispositivo(ds.DispositivoRepository, serial)
+	if err != nil {
+		return nil, err
+	}
+	return ds.salvarDispositivo(*dispositivo, dispositivoDTO.Produto, dispositivoDTO.CustomerID, dispositivoDTO.DataCompra)
+}
+
+// HistoricoDispositivo devolve todos os chamados do serial, do mais antigo ao
+// mais recente. Um dispositivo sem chamados resulta em lista vazia.
+func (ds *DispositivoService) HistoricoDispositivo(serial string) ([]entity.ChamadoEntity, error) {
+	if _, err := buscarDispositivo(ds.DispositivoRepository, serial); err != nil {
+		return nil, err
+	}
+	chamados, err := ds.ChamadoRepository.FindHistoricoBySerial(serial)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar histórico do dispositivo %q: %w", serial, err)
+	}
+	if chamados == nil {
+		chamados = []entity.ChamadoEntity{}
+	}
+	return chamados, nil
+}
+
+func (ds *DispositivoService) salvarDispositivo(dispositivo entity.DispositivoEntity, produto string, customerID int64, dataCompra string) (*entity.DispositivoEntity, error) {
+	compra, err := lerDataCompra(dataCompra)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := buscarCliente(ds.ClienteRepository, customerID); err != nil {
+		return nil, err
+	}
+
+	dispositivo.Produto = produto
+	dispositivo.CustomerID = customerID
+	dispositivo.DataCompra = compra
+
+	dispositivoSalvo, err := ds.DispositivoRepository.Save(dispositivo)
+	if errors.Is(err, repository.ErrRegistroDuplicado) {
+		return nil, serialDuplicado(dispositivo.SerialNumber)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar dispositivo: %w", err)
+	}
+	return &dispositivoSalvo, nil
+}
+
+// lerDataCompra aceita a data vazia (compra desconhecida), mas não uma data
+// no futuro.
+func lerDataCompra(valor string) (time.Time, error) {
+	if valor == "" {
+		return time.Time{}, nil
+	}
+	data, err := time.Parse(dto.FormatoDataCompra, valor)
+	if err != nil {
+		return time.Time{}, &Exception.ValidationException{
+			Message: "Dados inválidos.",
+			Campos:  map[string]string{"data_compra": "data inválida (use o formato AAAA-MM-DD)"},
+		}
+	}
+	if data.After(time.Now()) {
+		return time.Time{}, &Exception.ValidationException{
+			Message: "Dados inválidos.",
+			Campos:  map[string]string{"data_compra": "não pode estar no futuro"},
+		}
+	}
+	return data, nil
+}
+
+func buscarDispositivo(repo repository.DispositivoRepository, serial string) (*entity.DispositivoEntity, error) {
+	dispositivo, err := repo.FindBySerial(serial)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar dispositivo %q: %w", serial, err)
+	}
+	if dispositivo == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Dispositivo", Chave: serial}
+	}
+	return dispositivo, nil
+}
+
+func serialDuplicado(serial string) error {
+	return &Exception.ConflictException{
+		Message: "Já existe um dispositivo com o serial " + serial + ".",
+		Uri:     "/api/v1/dispositivos/" + serial,
+		Codigo:  Exception.CodigoDispositivoDuplicado,
+	}
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"helpdesk/dto"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+const mesesGarantiaPadrao = 12
+
+// ProvedorGarantia informa a garantia de um aparelho pelo serial. É
+// consultado na abertura de cada chamado; integrações com fabricantes podem
+// substituir a implementação padrão, GarantiaPorDataCompra.
+type ProvedorGarantia interface {
+	ConsultarGarantia(serial string) (model.StatusGarantia, error)
+}
+
+// RegraGarantia escolhe o balcão do chamado a partir da garantia. Devolve o
+// próprio idBalcao quando a regra não se aplica.
+type RegraGarantia func(garantia model.StatusGarantia, idBalcao int64) int64
+
+// EncaminharForaDaGarantia manda os chamados de aparelhos fora da garantia
+// para o balcão de reparo pago.
+func EncaminharForaDaGarantia(idBalcaoReparoPago int64) RegraGarantia {
+	return func(garantia model.StatusGarantia, idBalcao int64) int64 {
+		if garantia == model.ForaDaGarantia {
+			return idBalcaoReparoPago
+		}
+		return idBalcao
+	}
+}
+
+// GarantiaPorDataCompra considera o aparelho em garantia até Meses meses
+// depois da data de compra do cadastro de dispositivos. Sem dispositivo ou
+// sem data de compra a garantia é desconhecida.
+type GarantiaPorDataCompra struct {
+	Dispositivos repository.DispositivoRepository
+	Meses        int
+}
+
+func (g *GarantiaPorDataCompra) ConsultarGarantia(serial string) (model.StatusGarantia, error) {
+	dispositivo, err := g.Dispositivos.FindBySerial(serial)
+	if err != nil {
+		return "", fmt.Errorf("Erro ao buscar dispositivo %q: %w", serial, err)
+	}
+	if dispositivo == nil || dispositivo.DataCompra.IsZero() {
+		return model.GarantiaDesconhecida, nil
+	}
+	return garantiaAte(dispositivo.DataCompra.AddDate(0, g.Meses, 0)), nil
+}
+
+// GarantiaArquivo lê as garantias de um CSV com uma linha por aparelho no
+// formato "serial,AAAA-MM-DD", em que a data é o último dia da garantia.
+// Seriais fora do arquivo são consultados na Alternativa, se houver.
+type GarantiaArquivo struct {
+	validades   map[string]time.Time
+	Alternativa ProvedorGarantia
+}
+
+func CarregarGarantiaArquivo(caminho string, alternativa ProvedorGarantia) (*GarantiaArquivo, error) {
+	arquivo, err := os.Open(caminho)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir arquivo de garantias: %w", err)
+	}
+	defer arquivo.Close()
+
+	leitor := csv.NewReader(arquivo)
+	leitor.FieldsPerRecord = 2
+	leitor.Comment = '#'
+
+	validades := map[string]time.Time{}
+	for {
+		registro, err := leitor.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %w", caminho, err)
+		}
+		serial := strings.TrimSpace(registro[0])
+		validade, err := time.ParseInLocation(dto.FormatoDataCompra, strings.TrimSpace(registro[1]), time.Local)
+		if err != nil {
+			linha, _ := leitor.FieldPos(1)
+			return nil, fmt.Errorf("erro ao ler %s, linha %d: data %q inválida (use AAAA-MM-DD)", caminho, linha, registro[1])
+		}
+		validades[serial] = validade
+	}
+	return &GarantiaArquivo{validades: validades, Alternativa: alternativa}, nil
+}
+
+func (g *GarantiaArquivo) ConsultarGarantia(serial string) (model.StatusGarantia, error) {
+	validade, ok := g.validades[serial]
+	if ok {
+		return garantiaAte(validade.AddDate(0, 0, 1)), nil
+	}
+	if g.Alternativa != nil {
+		return g.Alternativa.ConsultarGarantia(serial)
+	}
+	return model.GarantiaDesconhecida, nil
+}
+
+// garantiaAte diz se hoje ainda é antes do fim da garantia.
+func garantiaAte(fim time.Time) model.StatusGarantia {
+	if time.Now().Before(fim) {
+		return model.EmGarantia
+	}
+	return model.ForaDaGarantia
+}
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// PaginaChamados é uma página da listagem. Total conta todos os chamados que
+// atendem ao filtro. Proximo e Anterior só são preenchidos na paginação por
+// cursor e ficam vazios quando não há página naquela direção.
+type PaginaChamados struct {
+	Chamados []entity.ChamadoEntity
+	Total    int64
+	Proximo  string
+	Anterior string
+}
+
+// cursorChamados é o conteúdo do cursor entregue ao cliente. Ele guarda a
+// ordenação para que não seja usado com outra.
+type cursorChamados struct {
+	Campo       model.CampoOrdenacao `json:"c"`
+	Decrescente bool                 `json:"d,omitempty"`
+	Valor       string               `json:"v"`
+	ID          int64                `json:"i"`
+	Anterior    bool                 `json:"a,omitempty"`
+}
+
+func (cs *ChamadoService) BuscarChamados(consulta dto.ListarChamadosDTO) (*PaginaChamados, error) {
+	if !consulta.Ordem.Campo.Valido() {
+		consulta.Ordem.Campo = model.OrdenarPorID
+	}
+
+	total, err := cs.chamadoRepository.ContarChamados(consulta.Filtro)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao contar chamados: %w", err)
+	}
+
+	if !consulta.PorCursor {
+		chamados, err := cs.chamadoRepository.BuscarChamados(model.ConsultaChamados{
+			Filtro: consulta.Filtro,
+			Ordem:  consulta.Ordem,
+			Limite: consulta.PageSize,
+			Offset: consulta.Page * consulta.PageSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Erro ao buscar chamados: %w", err)
+		}
+		return &PaginaChamados{Chamados: chamados, Total: total}, nil
+	}
+
+	pagina, err := cs.buscarPorCursor(consulta)
+	if err != nil {
+		return nil, err
+	}
+	pagina.Total = total
+	return pagina, nil
+}
+
+// buscarPorCursor pede um chamado a mais que o tamanho da página para saber
+// se há outra página na direção pedida. A página anterior é buscada na ordem
+// invertida a partir do primeiro chamado e depois desvirada.
+func (cs *ChamadoService) buscarPorCursor(consulta dto.ListarChamadosDTO) (*PaginaChamados, error) {
+	var cursor *cursorChamados
+	if consulta.Cursor != "" {
+		lido, err := decodificarCursor(consulta.Cursor, consulta.Ordem)
+		if err != nil {
+			return nil, err
+		}
+		cursor = lido
+	}
+
+	busca := model.ConsultaChamados{Filtro: consulta.Filtro, Ordem: consulta.Ordem, Limite: consulta.PageSize + 1}
+	voltando := cursor != nil && cursor.Anterior
+	if cursor != nil {
+		posicao, err := cursor.posicao()
+		if err != nil {
+			return nil, err
+		}
+		busca.Apos = &posicao
+	}
+	if voltando {
+		busca.Ordem = consulta.Ordem.Invertida()
+	}
+
+	chamados, err := cs.chamadoRepository.BuscarChamados(busca)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar chamados: %w", err)
+	}
+	temMais := len(chamados) > consulta.PageSize
+	if temMais {
+		chamados = chamados[:consulta.PageSize]
+	}
+	if voltando {
+		slices.Reverse(chamados)
+	}
+
+	pagina := &PaginaChamados{Chamados: chamados}
+	if len(chamados) == 0 {
+		return pagina, nil
+	}
+	primeiro, ultimo := chamados[0], chamados[len(chamados)-1]
+	if (voltando && temMais) || (!voltando && cursor != nil) {
+		pagina.Anterior = codificarCursor(consulta.Ordem, primeiro, true)
+	}
+	if (!voltando && temMais) || voltando {
+		pagina.Proximo = codificarCursor(consulta.Ordem, ultimo, false)
+	}
+	return pagina, nil
+}
+
+func codificarCursor(ordem model.OrdemChamados, chamado entity.ChamadoEntity, anterior bool) string {
+	var valor string
+	switch v := ordem.Campo.Valor(chamado.Chamado).(type) {
+	case int64:
+		valor = strconv.FormatInt(v, 10)
+	case time.Time:
+		valor = v.Format(time.RFC3339Nano)
+	case string:
+		valor = v
+	}
+
+	conteudo, _ := json.Marshal(cursorChamados{
+		Campo:       ordem.Campo,
+		Decrescente: ordem.Decrescente,
+		Valor:       valor,
+		ID:          chamado.ID,
+		Anterior:    anterior,
+	})
+	return base64.RawURLEncoding.EncodeToString(conteudo)
+}
+
+func decodificarCursor(token string, ordem model.OrdemChamados) (*cursorChamados, error) {
+	conteudo, err := base64.RawURLEncoding.DecodeString(token)
+	var cursor cursorChamados
+	if err == nil {
+		err = json.Unmarshal(conteudo, &cursor)
+	}
+	if err != nil {
+		return nil, cursorInvalido("não foi gerado por esta API")
+	}
+	if cursor.Campo != ordem.Campo || cursor.Decrescente != ordem.Decrescente {
+		return nil, cursorInvalido("foi gerado para outra ordenação")
+	}
+	return &cursor, nil
+}
+
+// posicao converte o valor do cursor para o tipo do campo ordenado.
+func (c *cursorChamados) posicao() (model.PosicaoChamado, error) {
+	posicao := model.PosicaoChamado{ID: c.ID}
+	switch c.Campo.Valor(model.Chamado{}).(type) {
+	case int64:
+		valor, err := strconv.ParseInt(c.Valor, 10, 64)
+		if err != nil {
+			return posicao, cursorInvalido("não foi gerado por esta API")
+		}
+		posicao.Valor = valor
+	case time.Time:
+		valor, err := time.Parse(time.RFC3339Nano, c.Valor)
+		if err != nil {
+			return posicao, cursorInvalido("não foi gerado por esta API")
+		}
+		posicao.Valor = valor
+	default:
+		posicao.Valor = c.Valor
+	}
+	return posicao, nil
+}
+
+func cursorInvalido(motivo string) error {
+	return &Exception.ValidationException{
+		Message: "Cursor inválido.",
+		Campos:  map[string]string{"cursor": motivo},
+	}
+}
+
+// ContarPorCategoria resume os chamados do filtro por categoria de produto.
+func (cs *ChamadoService) ContarPorCategoria(filtro model.FiltroChamados) ([]model.TotalCategoria, error) {
+	totais, err := cs.chamadoRepository.ContarPorCategoria(filtro)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao contar chamados por categoria: %w", err)
+	}
+	if totais == nil {
+		totais = []model.TotalCategoria{}
+	}
+	return totais, nil
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/repository"
+	"strings"
+)
+
+type ProdutoService struct {
+	ProdutoRepository repository.ProdutoRepository
+	BalcaoRepository  repository.BalcaoRepository
+}
+
+func NovoProdutoService(produtoRepo repository.ProdutoRepository, balcaoRepo repository.BalcaoRepository) *ProdutoService {
+	return &ProdutoService{ProdutoRepository: produtoRepo, BalcaoRepository: balcaoRepo}
+}
+
+func (ps *ProdutoService) CadastrarProduto(produtoDTO *dto.ProdutoDTO) (*entity.ProdutoEntity, error) {
+	if produtoDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O produto não pode ser nulo."}
+	}
+	return ps.salvarProduto(entity.ProdutoEntity{}, produtoDTO)
+}
+
+func (ps *ProdutoService) ListarProdutos() ([]entity.ProdutoEntity, error) {
+	return ps.ProdutoRepository.FindAll()
+}
+
+func (ps *ProdutoService) DetalharProduto(id int64) (*entity.ProdutoEntity, error) {
+	return buscarProduto(ps.ProdutoRepository, id)
+}
+
+func (ps *ProdutoService) EditarProduto(id int64, produtoDTO *dto.ProdutoDTO) (*entity.ProdutoEntity, error) {
+	if produtoDTO == nil {
+		return nil, &Exception.ValidationException{Message: "O produto não pode ser nulo."}
+	}
+	produto, err := buscarProduto(ps.ProdutoRepository, id)
+	if err != nil {
+		return nil, err
+	}
+	return ps.salvarProduto(*produto, produtoDTO)
+}
+
+// salvarProduto aplica os dados do DTO ao produto e o grava. O SKU é guardado
+// em maiúsculas e é único; o balcão padrão, se informado, precisa existir.
+func (ps *ProdutoService) salvarProduto(produto entity.ProdutoEntity, produtoDTO *dto.ProdutoDTO) (*entity.ProdutoEntity, error) {
+	sku := strings.ToUpper(produtoDTO.SKU)
+
+	existente, err := ps.ProdutoRepository.FindBySku(sku)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar produto pelo SKU: %w", err)
+	}
+	if existente != nil && existente.ID != produto.ID {
+		return nil, skuDuplicado(sku, existente.ID)
+	}
+
+	if produtoDTO.IDBalcaoPadrao != 0 {
+		balcao, err := ps.BalcaoRepository.FindById(produtoDTO.IDBalcaoPadrao)
+		if err != nil {
+			return nil, fmt.Errorf("Erro ao buscar balcão com ID %d: %w", produtoDTO.IDBalcaoPadrao, err)
+		}
+		if balcao == nil {
+			return nil, &Exception.NotFoundException{Recurso: "Balcão", ID: produtoDTO.IDBalcaoPadrao}
+		}
+	}
+
+	produto.SKU = sku
+	produto.Nome = produtoDTO.Nome
+	produto.Categoria = produtoDTO.Categoria
+	produto.IDBalcaoPadrao = produtoDTO.IDBalcaoPadrao
+
+	produtoSalvo, err := ps.ProdutoRepository.Save(produto)
+	if errors.Is(err, repository.ErrRegistroDuplicado) {
+		return nil, skuDuplicado(sku, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao salvar produto: %w", err)
+	}
+	return &produtoSalvo, nil
+}
+
+func buscarProduto(repo repository.ProdutoRepository, id int64) (*entity.ProdutoEntity, error) {
+	produto, err := repo.FindById(id)
+	if err != nil {
+		return nil, fmt.Errorf("Erro ao buscar produto com ID %d: %w", id, err)
+	}
+	if produto == nil {
+		return nil, &Exception.NotFoundException{Recurso: "Produto", ID: id}
+	}
+	return produto, nil
+}
+
+func skuDuplicado(sku string, idExistente int64) error {
+	uri := "/api/v1/produtos"
+	if idExistente != 0 {
+		uri = fmt.Sprintf("/api/v1/produtos/%d", idExistente)
+	}
+	return &Exception.ConflictException{
+		Message: "Já existe um produto com o SKU " + sku + ".",
+		Uri:     uri,
+		Codigo:  Exception.CodigoProdutoDuplicado,
+	}
+}
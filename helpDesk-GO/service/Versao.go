@@ -0,0 +1,25 @@
+package service
+
+import (
+	"errors"
+	"helpdesk/Exception"
+	"helpdesk/repository"
+)
+
+// conferirVersao compara a versão lida com a que o cliente esperava editar.
+// Esperada zero dispensa a verificação.
+func conferirVersao(recurso string, id, esperada, atual int64) error {
+	if esperada != 0 && esperada != atual {
+		return &Exception.VersaoDesatualizadaException{Recurso: recurso, ID: id, Esperada: esperada, Atual: atual}
+	}
+	return nil
+}
+
+// erroDeVersao traduz o conflito de versão detectado pelo repositório, que
+// acontece quando outra escrita passa entre a leitura e o Save.
+func erroDeVersao(recurso string, id int64, err error) error {
+	if errors.Is(err, repository.ErrVersaoDesatualizada) {
+		return &Exception.VersaoDesatualizadaException{Recurso: recurso, ID: id}
+	}
+	return err
+}
@@ -0,0 +1,112 @@
+package configTest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"helpdesk/config"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func ambiente(valores map[string]string) func(string) (string, bool) {
+	return func(chave string) (string, bool) {
+		valor, ok := valores[chave]
+		return valor, ok
+	}
+}
+
+func TestCarregarPadroes(t *testing.T) {
+	cfg, err := config.CarregarDe(ambiente(map[string]string{
+		"HELPDESK_DSN": "user:senha@tcp(127.0.0.1:3306)/helpdesk",
+	}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "mysql", cfg.Storage)
+	assert.Equal(t, ":8080", cfg.ListenAddr)
+	assert.Equal(t, 5, cfg.LimiteFila)
+	assert.Equal(t, 15*time.Second, cfg.ShutdownTimeout)
+	assert.Equal(t, slog.LevelInfo, cfg.LogLevel)
+	assert.Equal(t, 12, cfg.GarantiaMeses)
+	assert.Equal(t, int64(0), cfg.BalcaoReparoPago)
+	assert.Contains(t, cfg.DSN, "parseTime=true")
+}
+
+func TestCarregarArquivoComAmbienteSobrescrevendo(t *testing.T) {
+	for _, arquivo := range []struct{ nome, conteudo string }{
+		{"helpdesk.yaml", "storage: memory\nlimite_fila: 3\nlisten_addr: \":9090\"\n"},
+		{"helpdesk.toml", "storage = \"memory\"\nlimite_fila = 3\nlisten_addr = \":9090\"\n"},
+	} {
+		t.Run(arquivo.nome, func(t *testing.T) {
+			caminho := filepath.Join(t.TempDir(), arquivo.nome)
+			assert.NoError(t, os.WriteFile(caminho, []byte(arquivo.conteudo), 0o600))
+
+			cfg, err := config.CarregarDe(ambiente(map[string]string{
+				"HELPDESK_CONFIG":      caminho,
+				"HELPDESK_LISTEN_ADDR": ":7070",
+			}))
+
+			assert.NoError(t, err)
+			assert.Equal(t, "memory", cfg.Storage)
+			assert.Equal(t, 3, cfg.LimiteFila)
+			assert.Equal(t, ":7070", cfg.ListenAddr)
+		})
+	}
+}
+
+func TestCarregarListaChavesInvalidas(t *testing.T) {
+	_, err := config.CarregarDe(ambiente(map[string]string{
+		"HELPDESK_STORAGE":            "postgres",
+		"HELPDESK_LIMITE_FILA":        "0",
+		"HELPDESK_READ_TIMEOUT":       "rapido",
+		"HELPDESK_LOG_LEVEL":          "verbose",
+		"HELPDESK_BALCAO_REPARO_PAGO": "-1",
+	}))
+
+	var erroConfig *config.ErroConfig
+	if assert.True(t, errors.As(err, &erroConfig)) {
+		assert.Contains(t, erroConfig.Chaves, "storage")
+		assert.Contains(t, erroConfig.Chaves, "limite_fila")
+		assert.Contains(t, erroConfig.Chaves, "read_timeout")
+		assert.Contains(t, erroConfig.Chaves, "log_level")
+		assert.Contains(t, erroConfig.Chaves, "balcao_reparo_pago")
+		assert.NotContains(t, erroConfig.Chaves, "listen_addr")
+	}
+}
+
+func TestCarregarExigeDSNParaMySQL(t *testing.T) {
+	_, err := config.CarregarDe(ambiente(nil))
+
+	assert.EqualError(t, err, "configuração inválida: dsn: obrigatório quando storage é mysql")
+}
+
+func TestCarregarRejeitaChaveDesconhecidaNoArquivo(t *testing.T) {
+	caminho := filepath.Join(t.TempDir(), "helpdesk.yaml")
+	assert.NoError(t, os.WriteFile(caminho, []byte("storage: memory\nporta: 80\n"), 0o600))
+
+	_, err := config.CarregarDe(ambiente(map[string]string{"HELPDESK_CONFIG": caminho}))
+
+	assert.EqualError(t, err, "configuração inválida: porta: chave desconhecida")
+}
+
+func TestCarregarRelataChaveDesconhecidaJuntoDosDemaisProblemas(t *testing.T) {
+	caminho := filepath.Join(t.TempDir(), "helpdesk.yaml")
+	assert.NoError(t, os.WriteFile(caminho, []byte("storage: memory\nporta: 80\nlimite_fila: 0\n"), 0o600))
+
+	_, err := config.CarregarDe(ambiente(map[string]string{"HELPDESK_CONFIG": caminho}))
+
+	assert.EqualError(t, err, `configuração inválida: limite_fila: valor "0" inválido (deve ser um inteiro maior que zero); porta: chave desconhecida`)
+}
+
+func TestCarregarIgnoraValorNuloNoArquivo(t *testing.T) {
+	caminho := filepath.Join(t.TempDir(), "helpdesk.yaml")
+	assert.NoError(t, os.WriteFile(caminho, []byte("storage: memory\nlisten_addr: null\nlog_level:\n"), 0o600))
+
+	cfg, err := config.CarregarDe(ambiente(map[string]string{"HELPDESK_CONFIG": caminho}))
+
+	assert.NoError(t, err)
+	assert.Equal(t, ":8080", cfg.ListenAddr)
+	assert.Equal(t, slog.LevelInfo, cfg.LogLevel)
+}
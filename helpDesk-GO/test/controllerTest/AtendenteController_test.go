@@ -0,0 +1,144 @@
+package controllerTest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// cadastrarAtendente cria um atendente e devolve o ID gerado.
+func cadastrarAtendente(router http.Handler, login, nome string) int64 {
+	rec := requisitar(router, http.MethodPost, "/api/v1/atendentes", map[string]any{"login": login, "nome": nome})
+
+	var resposta struct {
+		Data struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resposta)
+	return resposta.Data.ID
+}
+
+// cadastrarBalcao cria um atendente com o login informado e um balcão para
+// ele.
+func cadastrarBalcao(router http.Handler, login string, capacidade int) *httptest.ResponseRecorder {
+	idAtendente := cadastrarAtendente(router, login, login)
+	return requisitar(router, http.MethodPost, "/api/v1/balcoes", map[string]any{"id_atendente": idAtendente, "capacidade": capacidade})
+}
+
+func TestRecursoAtendente(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/atendentes", map[string]any{
+		"login":       "maria",
+		"nome":        "Maria",
+		"habilidades": []string{"notebook", " Impressora ", "NOTEBOOK"},
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/atendentes/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var atendente map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &atendente)
+	assert.Equal(t, map[string]any{
+		"id":          float64(1),
+		"login":       "maria",
+		"nome":        "Maria",
+		"ativo":       true,
+		"habilidades": []any{"notebook", "Impressora"},
+	}, atendente)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/balcoes", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/atendentes/1", map[string]any{"nome": "Maria Souza"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/balcoes/1", nil)
+	var balcao map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &balcao)
+	assert.Equal(t, "Maria Souza", balcao["nome_atendente"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/atendentes", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var atendentes []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &atendentes)
+	assert.Len(t, atendentes, 1)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/atendentes/9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCadastrarAtendenteComLoginRepetido(t *testing.T) {
+	router := novoRouterMemoria()
+
+	cadastrarAtendente(router, "joao", "João")
+	rec := requisitar(router, http.MethodPost, "/api/v1/atendentes", map[string]any{"login": "JOAO", "nome": "Outro João"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "ATENDENTE_DUPLICADO", problema["codigo"])
+}
+
+func TestCadastrarAtendenteValidaCampos(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/atendentes", map[string]any{"login": "joão silva"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"login": "formato inválido (use de 2 a 64 letras, números, pontos, hífens ou sublinhados)",
+		"nome":  "obrigatório",
+	}, problema["campos"])
+}
+
+func TestAtendenteInativo(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	cadastrarAtendente(router, "maria", "Maria")
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_balcao": 1})
+	requisitar(router, http.MethodPost, "/api/v1/chamados/1/assumir", map[string]any{"id_atendente": 1})
+
+	rec := requisitar(router, http.MethodDelete, "/api/v1/atendentes/1", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "ATENDENTE_OCUPADO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodDelete, "/api/v1/atendentes/2", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/balcoes", map[string]any{"id_atendente": 2})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "ATENDENTE_INATIVO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/resolver", map[string]any{"id_atendente": 2})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "ATENDENTE_INATIVO", problema["codigo"])
+}
+
+func TestAtendenteComBalcaoNaoPodeSerDesativado(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarBalcao(router, "joao", 0)
+
+	rec := requisitar(router, http.MethodDelete, "/api/v1/atendentes/1", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "ATENDENTE_COM_BALCAO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/atendentes/1", map[string]any{"nome": "João", "ativo": false})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/atendentes/1", nil)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, true, problema["ativo"])
+}
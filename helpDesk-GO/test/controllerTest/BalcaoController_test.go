@@ -0,0 +1,160 @@
+package controllerTest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestCadastrarBalcaoDuplicado(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := cadastrarBalcao(router, "maria", 0)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/balcoes", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "BALCAO_DUPLICADO", problema["codigo"])
+}
+
+func TestEditarBalcaoInexistente(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitarComIfMatch(router, http.MethodPut, "/api/v1/balcoes/9", "*", map[string]any{"id": 9, "id_atendente": 1})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCapacidadeDoBalcao(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+
+	rec := cadastrarBalcao(router, "maria", 2)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	for _, serial := range []string{"SN-1", "SN-2", "SN-3"} {
+		rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	}
+
+	var terceiro map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &terceiro)
+	assert.Equal(t, "AGUARDANDO", terceiro["status_chamado"])
+
+	rec = requisitarComIfMatch(router, http.MethodPut, "/api/v1/balcoes/1", "*", map[string]any{"id": 1, "id_atendente": 1, "capacidade": 1})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = requisitarComIfMatch(router, http.MethodPut, "/api/v1/balcoes/1", "*", map[string]any{"id": 1, "id_atendente": 1, "capacidade": 4})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var balcao map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &balcao)
+	assert.Equal(t, float64(4), balcao["capacidade"])
+	assert.Equal(t, float64(2), balcao["fila_atendimento"])
+}
+
+func TestCadastrarBalcaoValidaCampos(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/balcoes", map[string]any{"capacidade": -1})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"id_atendente": "obrigatório",
+		"capacidade":   "deve ser maior ou igual a 0",
+	}, problema["campos"])
+}
+
+func TestEdicaoConcorrenteDoBalcao(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := cadastrarBalcao(router, "maria", 0)
+	etag := rec.Header().Get("ETag")
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/balcoes/1", map[string]any{"id_atendente": 1, "capacidade": 3})
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+
+	rec = requisitarComIfMatch(router, http.MethodPut, "/api/v1/balcoes/1", etag, map[string]any{"id_atendente": 1, "capacidade": 3})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requisitarComIfMatch(router, http.MethodPut, "/api/v1/balcoes/1", etag, map[string]any{"id_atendente": 1, "capacidade": 4})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+}
+
+func TestRecursoBalcao(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "maria", 1)
+	cadastrarBalcao(router, "joao", 0)
+	cadastrarAtendente(router, "maria.souza", "Maria Souza")
+	for _, serial := range []string{"SN-1", "SN-2"} {
+		requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+	}
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/balcoes", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var balcoes []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &balcoes)
+	assert.Len(t, balcoes, 2)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/balcoes/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var balcao map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &balcao)
+	assert.Equal(t, true, balcao["ativo"])
+	assert.Equal(t, map[string]any{
+		"em_atendimento": float64(1),
+		"capacidade":     float64(1),
+		"vagas_livres":   float64(0),
+		"aguardando":     float64(1),
+	}, balcao["ocupacao"])
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/balcoes/1", rec.Header().Get("ETag"), map[string]any{"id_atendente": 3})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &balcao)
+	assert.Equal(t, float64(3), balcao["id_atendente"])
+	assert.Equal(t, "Maria Souza", balcao["nome_atendente"])
+	assert.Equal(t, float64(1), balcao["capacidade"])
+
+	rec = requisitar(router, http.MethodDelete, "/api/v1/balcoes/1", nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "BALCAO_COM_FILA", problema["codigo"])
+
+	rec = requisitar(router, http.MethodDelete, "/api/v1/balcoes/2", nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/balcoes/2", nil)
+	json.Unmarshal(rec.Body.Bytes(), &balcao)
+	assert.Equal(t, false, balcao["ativo"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-3", "id_balcao": 2})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "BALCAO_INATIVO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodDelete, "/api/v1/balcoes/9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestFilaNaoMudaETagDoBalcao(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	rec := cadastrarBalcao(router, "joao", 0)
+	etag := rec.Header().Get("ETag")
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_balcao": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/balcoes/1", nil)
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/balcoes/1", etag, map[string]any{"capacidade": 3})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
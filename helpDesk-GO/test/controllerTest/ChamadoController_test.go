@@ -0,0 +1,459 @@
+package controllerTest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"helpdesk/controller"
+	"helpdesk/repository"
+	"helpdesk/server"
+	"helpdesk/service"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func novoRouterMemoria() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+	repos := unidade.Repositorios()
+
+	chamadoService := service.NovoChamadoServiceTransacional(unidade)
+	balcaoService := service.NovoBalcaoServiceTransacional(unidade)
+	atendenteService := service.NovoAtendenteServiceTransacional(unidade)
+
+	return server.NovoRouter(
+		controller.NovoChamadoController(chamadoService),
+		controller.NewBalcaoController(balcaoService),
+		controller.NovoAtendenteController(atendenteService),
+		controller.NovoClienteController(service.NovoClienteService(repos.Clientes)),
+		controller.NovoDispositivoController(service.NovoDispositivoService(repos.Dispositivos, repos.Clientes, repos.Chamados)),
+		controller.NovoProdutoController(service.NovoProdutoService(repos.Produtos, repos.Balcoes)),
+	)
+}
+
+func requisitar(router http.Handler, metodo, caminho string, corpo any) *httptest.ResponseRecorder {
+	return requisitarComIfMatch(router, metodo, caminho, "", corpo)
+}
+
+func requisitarComIfMatch(router http.Handler, metodo, caminho, ifMatch string, corpo any) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if corpo != nil {
+		json.NewEncoder(&buf).Encode(corpo)
+	}
+	req := httptest.NewRequest(metodo, caminho, &buf)
+	req.Header.Set("Content-Type", "application/json")
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCriarEDetalharChamado(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+
+	rec := cadastrarBalcao(router, "joao", 0)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{
+		"customer_id":   1,
+		"serial_number": "SN-1",
+		"id_balcao":     1,
+		"motivo":        "Tela quebrada",
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var criado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &criado)
+	assert.Equal(t, "ABERTO", criado["status_chamado"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{
+		"customer_id":   1,
+		"serial_number": "SN-1",
+		"id_balcao":     1,
+	})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestDetalharChamadoInexistente(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados/42", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCicloDeVidaDoChamado(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+
+	cadastrarBalcao(router, "joao", 0)
+	cadastrarAtendente(router, "maria", "Maria")
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{
+		"customer_id":   1,
+		"serial_number": "SN-1",
+		"id_balcao":     1,
+	})
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados/1/resolver", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/assumir", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var chamado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "EM_ANDAMENTO", chamado["status_chamado"])
+	assert.Equal(t, float64(1), chamado["id_atendente"])
+	assert.Equal(t, "joao", chamado["user_atendente"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/resolver", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "RESOLVIDO", chamado["status_chamado"])
+	assert.NotEqual(t, "0001-01-01T00:00:00Z", chamado["data_resolution"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/reabrir", map[string]any{"id_atendente": 2})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "ABERTO", chamado["status_chamado"])
+	assert.Equal(t, "maria", chamado["user_ultima_acao"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/fechar", map[string]any{"id_atendente": 2})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestAcaoChamadoExigeAtendente(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados/1/assumir", map[string]any{})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/assumir", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	cadastrarAtendente(router, "joao", "João")
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/assumir", map[string]any{"id_atendente": 1})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestErrosRespondemProblemJSON(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados/42", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, float64(http.StatusNotFound), problema["status"])
+	assert.Equal(t, "NAO_ENCONTRADO", problema["codigo"])
+	assert.Equal(t, "/api/v1/chamados/42", problema["instance"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/abc", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "VALIDACAO", problema["codigo"])
+	assert.Equal(t, map[string]any{"id": "deve ser um número inteiro"}, problema["campos"])
+}
+
+func TestCriarChamadoValidaCampos(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+
+	tests := []struct {
+		name           string
+		corpo          map[string]any
+		expectedCampos map[string]any
+	}{
+		{
+			name:  "Campos obrigatórios ausentes",
+			corpo: map[string]any{"motivo": "Tela quebrada"},
+			expectedCampos: map[string]any{
+				"customer_id":   "obrigatório",
+				"serial_number": "obrigatório",
+				"id_balcao":     "obrigatório",
+			},
+		},
+		{
+			name:  "IDs negativos e serial mal formatado",
+			corpo: map[string]any{"customer_id": -1, "serial_number": "SN 1!", "id_balcao": -2},
+			expectedCampos: map[string]any{
+				"customer_id":   "deve ser maior que 0",
+				"serial_number": "formato inválido (use de 3 a 100 letras, números ou hífens)",
+				"id_balcao":     "deve ser maior que 0",
+			},
+		},
+		{
+			name:           "Tipo errado",
+			corpo:          map[string]any{"customer_id": "um", "serial_number": "SN-1", "id_balcao": 1},
+			expectedCampos: map[string]any{"customer_id": "deve ser do tipo int64"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := requisitar(router, http.MethodPost, "/api/v1/chamados", tt.corpo)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var problema map[string]any
+			json.Unmarshal(rec.Body.Bytes(), &problema)
+			assert.Equal(t, "VALIDACAO", problema["codigo"])
+			assert.Equal(t, tt.expectedCampos, problema["campos"])
+		})
+	}
+}
+
+func TestEditarChamadoValidaStatus(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitarComIfMatch(router, http.MethodPut, "/api/v1/chamados/1", "*", map[string]any{
+		"customer_id":    1,
+		"serial_number":  "SN-1",
+		"status_chamado": "CONCLUIDO",
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "VALIDACAO", problema["codigo"])
+	assert.Equal(t, map[string]any{"status_chamado": "status desconhecido"}, problema["campos"])
+}
+
+func TestCriarChamadoIgnoraCamposDoServidor(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{
+		"id":             99,
+		"customer_id":    1,
+		"serial_number":  "SN-1",
+		"id_balcao":      1,
+		"status_chamado": "FECHADO",
+		"user_atendente": "Intruso",
+		"data_creation":  "2000-01-01T00:00:00Z",
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var criado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &criado)
+	assert.Equal(t, float64(1), criado["id"])
+	assert.Equal(t, "ABERTO", criado["status_chamado"])
+	assert.Empty(t, criado["user_atendente"])
+	assert.NotEqual(t, "2000-01-01T00:00:00Z", criado["data_creation"])
+	assert.NotContains(t, criado, "data_resolution")
+}
+
+func TestAtualizarChamadoParcial(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{
+		"customer_id":   1,
+		"serial_number": "SN-1",
+		"id_balcao":     1,
+		"motivo":        "Tela quebrada",
+		"produto":       "Notebook",
+	})
+	var criado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &criado)
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", rec.Header().Get("ETag"), map[string]any{
+		"motivo":  "Tela e teclado quebrados",
+		"produto": nil,
+	})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var atualizado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &atualizado)
+	assert.Equal(t, "Tela e teclado quebrados", atualizado["motivo"])
+	assert.Equal(t, "", atualizado["produto"])
+	assert.Equal(t, "SN-1", atualizado["serial_number"])
+	assert.Equal(t, criado["data_creation"], atualizado["data_creation"])
+	assert.Equal(t, "ABERTO", atualizado["status_chamado"])
+
+	tests := []struct {
+		name           string
+		corpo          map[string]any
+		expectedCampos map[string]any
+	}{
+		{
+			name:           "Campos imutáveis",
+			corpo:          map[string]any{"id": 2, "customer_id": 2, "data_creation": "2000-01-01T00:00:00Z"},
+			expectedCampos: map[string]any{"id": "não pode ser alterado", "customer_id": "não pode ser alterado", "data_creation": "não pode ser alterado"},
+		},
+		{
+			name:           "Campo desconhecido",
+			corpo:          map[string]any{"user_atendente": "Maria"},
+			expectedCampos: map[string]any{"user_atendente": "campo desconhecido"},
+		},
+		{
+			name:           "Serial não pode ser limpo",
+			corpo:          map[string]any{"serial_number": nil},
+			expectedCampos: map[string]any{"serial_number": "formato inválido (use de 3 a 100 letras, números ou hífens)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", "*", tt.corpo)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+			var problema map[string]any
+			json.Unmarshal(rec.Body.Bytes(), &problema)
+			assert.Equal(t, tt.expectedCampos, problema["campos"])
+		})
+	}
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", "*", map[string]any{"status_chamado": "FECHADO"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestEdicaoConcorrenteDoChamado(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_balcao": 1})
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados/1", nil)
+	etag := rec.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag)
+
+	rec = requisitar(router, http.MethodPatch, "/api/v1/chamados/1", map[string]any{"motivo": "Sem If-Match"})
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Code)
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", etag, map[string]any{"motivo": "Primeira edição"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	rec = requisitarComIfMatch(router, http.MethodPut, "/api/v1/chamados/1", etag, map[string]any{
+		"customer_id":   1,
+		"serial_number": "SN-1",
+		"motivo":        "Segunda edição",
+	})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "VERSAO_DESATUALIZADA", problema["codigo"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/1", nil)
+	var chamado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "Primeira edição", chamado["motivo"])
+}
+
+func TestListarChamadosComFiltros(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 1)
+	for _, serial := range []string{"SN-1", "SN-2", "SN-3"} {
+		requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+	}
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados?status_chamado=AGUARDANDO&ordenar=-id&pageSize=1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+	assert.Equal(t, `</api/v1/chamados?ordenar=-id&page=1&pageSize=1&status_chamado=AGUARDANDO>; rel="next"`, rec.Header().Get("Link"))
+
+	var chamados []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamados)
+	if assert.Len(t, chamados, 1) {
+		assert.Equal(t, "SN-3", chamados[0]["serial_number"])
+	}
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?paginacao=cursor&pageSize=2", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "3", rec.Header().Get("X-Total-Count"))
+	assert.Contains(t, rec.Header().Get("Link"), `rel="next"`)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?pageSize=abc&status_chamado=CONCLUIDO&ordenar=motivo&criado_de=ontem", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"pageSize":       "deve ser um número inteiro",
+		"status_chamado": "status desconhecido: CONCLUIDO",
+		"ordenar":        "campo de ordenação desconhecido",
+		"criado_de":      "use o formato AAAA-MM-DD ou RFC 3339",
+	}, problema["campos"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?page=10001&pageSize=101", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"page":     "deve ser menor ou igual a 10000",
+		"pageSize": "deve ser menor ou igual a 100",
+	}, problema["campos"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?page=-1&pageSize=0", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"page":     "deve ser maior ou igual a 0",
+		"pageSize": "deve ser maior ou igual a 1",
+	}, problema["campos"])
+}
+
+func TestListarChamadosPorAtendente(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	cadastrarAtendente(router, "maria", "Maria")
+	for _, serial := range []string{"SN-1", "SN-2"} {
+		requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+	}
+	requisitar(router, http.MethodPost, "/api/v1/chamados/2/assumir", map[string]any{"id_atendente": 1})
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados?id_atendente=1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("X-Total-Count"))
+
+	var chamados []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamados)
+	if assert.Len(t, chamados, 1) {
+		assert.Equal(t, "SN-2", chamados[0]["serial_number"])
+	}
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?id_atendente=2", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "0", rec.Header().Get("X-Total-Count"))
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?id_atendente=joao", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{"id_atendente": "deve ser um número inteiro maior que 0"}, problema["campos"])
+}
+
+func TestEdicaoNaoAssumeNemTiraDaFila(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 1)
+	for _, serial := range []string{"SN-1", "SN-2"} {
+		requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+	}
+
+	var problema map[string]any
+	for id, status := range map[int]string{1: "EM_ANDAMENTO", 2: "ABERTO"} {
+		rec := requisitarComIfMatch(router, http.MethodPatch, fmt.Sprintf("/api/v1/chamados/%d", id), "*", map[string]any{"status_chamado": status})
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		json.Unmarshal(rec.Body.Bytes(), &problema)
+		assert.Equal(t, "TRANSICAO_INVALIDA", problema["codigo"])
+	}
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/chamados/2", nil)
+	var chamado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "AGUARDANDO", chamado["status_chamado"])
+}
@@ -0,0 +1,115 @@
+package controllerTest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+// cadastrarCliente cria um cliente e devolve o ID gerado.
+func cadastrarCliente(router http.Handler, nome, documento string) int64 {
+	rec := requisitar(router, http.MethodPost, "/api/v1/clientes", map[string]any{"nome": nome, "documento": documento})
+
+	var resposta struct {
+		Data struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &resposta)
+	return resposta.Data.ID
+}
+
+func TestRecursoCliente(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/clientes", map[string]any{
+		"nome":      "Oficina do Zé",
+		"documento": "11.222.333/0001-81",
+		"email":     "ze@oficina.com.br",
+		"telefone":  "+55 (11) 3333-4444",
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var cliente map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &cliente)
+	assert.Equal(t, map[string]any{
+		"id":        float64(1),
+		"nome":      "Oficina do Zé",
+		"documento": "11222333000181",
+		"email":     "ze@oficina.com.br",
+		"telefone":  "+55 (11) 3333-4444",
+	}, cliente)
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/clientes/1", map[string]any{"nome": "Oficina do José", "documento": "11222333000181"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &cliente)
+	assert.Equal(t, "Oficina do José", cliente["nome"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/clientes", map[string]any{"nome": "Outro", "documento": "11222333000181"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "CLIENTE_DUPLICADO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes", nil)
+	var clientes []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &clientes)
+	assert.Len(t, clientes, 1)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes/9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCadastrarClienteValidaCampos(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/clientes", map[string]any{
+		"documento": "529.982.247-24",
+		"email":     "ana@",
+		"telefone":  "ramal 12",
+	})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"nome":      "obrigatório",
+		"documento": "CPF ou CNPJ inválido",
+		"email":     "e-mail inválido",
+		"telefone":  "telefone inválido",
+	}, problema["campos"])
+}
+
+func TestChamadosDoCliente(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarCliente(router, "Bruno", "111.444.777-35")
+	cadastrarBalcao(router, "joao", 0)
+
+	rec := requisitar(router, http.MethodGet, "/api/v1/clientes/1/chamados", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	for _, serial := range []string{"SN-1", "SN-2"} {
+		requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": serial, "id_balcao": 1})
+	}
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 3, "serial_number": "SN-3", "id_balcao": 1})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes/1/chamados", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var chamados []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamados)
+	if assert.Len(t, chamados, 2) {
+		assert.Equal(t, "Ana", chamados[0]["user_client"])
+	}
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes/2/chamados", nil)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/clientes/9/chamados", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
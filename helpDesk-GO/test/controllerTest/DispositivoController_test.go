@@ -0,0 +1,107 @@
+package controllerTest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestRecursoDispositivo(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarCliente(router, "Bruno", "111.444.777-35")
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/dispositivos", map[string]any{
+		"serial_number": "SN-1",
+		"produto":       "Notebook",
+		"customer_id":   1,
+		"data_compra":   "2024-03-10",
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var dispositivo map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &dispositivo)
+	assert.Equal(t, map[string]any{
+		"id":            float64(1),
+		"serial_number": "SN-1",
+		"produto":       "Notebook",
+		"customer_id":   float64(1),
+		"data_compra":   "2024-03-10",
+	}, dispositivo)
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/dispositivos/SN-1", map[string]any{"produto": "Notebook X", "customer_id": 2})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	dispositivo = nil
+	json.Unmarshal(rec.Body.Bytes(), &dispositivo)
+	assert.Equal(t, float64(2), dispositivo["customer_id"])
+	assert.NotContains(t, dispositivo, "data_compra")
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/dispositivos", map[string]any{"serial_number": "SN-1", "customer_id": 1})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "DISPOSITIVO_DUPLICADO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCadastrarDispositivoValidaCampos(t *testing.T) {
+	router := novoRouterMemoria()
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/dispositivos", map[string]any{"serial_number": "S", "data_compra": "10/03/2024"})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, map[string]any{
+		"serial_number": "formato inválido (use de 3 a 100 letras, números ou hífens)",
+		"customer_id":   "obrigatório",
+		"data_compra":   "data inválida (use o formato AAAA-MM-DD)",
+	}, problema["campos"])
+}
+
+func TestHistoricoDispositivo(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "produto": "Impressora", "id_balcao": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var dispositivo map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &dispositivo)
+	assert.Equal(t, "Impressora", dispositivo["produto"])
+
+	for _, acao := range []string{"assumir", "resolver", "fechar"} {
+		rec = requisitar(router, http.MethodPost, "/api/v1/chamados/1/"+acao, map[string]any{"id_atendente": 1})
+		assert.Equal(t, http.StatusOK, rec.Code, acao)
+	}
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_balcao": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-1/historico", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var chamados []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamados)
+	if assert.Len(t, chamados, 2) {
+		assert.Equal(t, float64(1), chamados[0]["id"])
+		assert.Equal(t, "FECHADO", chamados[0]["status_chamado"])
+		assert.Equal(t, "DESCONHECIDA", chamados[0]["garantia"])
+		assert.Equal(t, float64(2), chamados[1]["id"])
+		assert.Equal(t, "Impressora", chamados[1]["produto"])
+	}
+
+	requisitar(router, http.MethodPost, "/api/v1/dispositivos", map[string]any{"serial_number": "SN-2", "customer_id": 1})
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-2/historico", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/dispositivos/SN-9/historico", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,128 @@
+package controllerTest
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestRecursoProduto(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarBalcao(router, "joao", 0)
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/produtos", map[string]any{
+		"sku":              "nb-100",
+		"nome":             "Notebook 100",
+		"categoria":        "Notebooks",
+		"id_balcao_padrao": 1,
+	})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/produtos/1", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var produto map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &produto)
+	assert.Equal(t, map[string]any{
+		"id":               float64(1),
+		"sku":              "NB-100",
+		"nome":             "Notebook 100",
+		"categoria":        "Notebooks",
+		"id_balcao_padrao": float64(1),
+	}, produto)
+
+	rec = requisitar(router, http.MethodPut, "/api/v1/produtos/1", map[string]any{"sku": "NB-100", "nome": "Notebook 100 Pro", "categoria": "Notebooks"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	produto = nil
+	json.Unmarshal(rec.Body.Bytes(), &produto)
+	assert.Equal(t, "Notebook 100 Pro", produto["nome"])
+	assert.NotContains(t, produto, "id_balcao_padrao")
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/produtos", map[string]any{"sku": "NB-100", "nome": "Outro", "categoria": "Notebooks"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	var problema map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &problema)
+	assert.Equal(t, "PRODUTO_DUPLICADO", problema["codigo"])
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/produtos", nil)
+	var produtos []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &produtos)
+	assert.Len(t, produtos, 1)
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/produtos/9", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestChamadoComProdutoDoCatalogo(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	cadastrarBalcao(router, "maria", 0)
+	requisitar(router, http.MethodPost, "/api/v1/produtos", map[string]any{"sku": "NB-100", "nome": "Notebook 100", "categoria": "Notebooks", "id_balcao_padrao": 2})
+	requisitar(router, http.MethodPost, "/api/v1/produtos", map[string]any{"sku": "IMP-1", "nome": "Impressora", "categoria": "Impressoras"})
+
+	rec := requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_produto": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var chamado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, float64(2), chamado["id_balcao"])
+	assert.Equal(t, float64(1), chamado["id_produto"])
+	assert.Equal(t, "Notebook 100", chamado["produto"])
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-2", "id_produto": 2})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-2", "id_produto": 2, "id_balcao": 1})
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-3", "id_balcao": 1})
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/3", "*", map[string]any{"id_produto": 9})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/3", "*", map[string]any{"id_produto": 2})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	chamado = nil
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.Equal(t, "Impressora", chamado["produto"])
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-4", "id_balcao": 1})
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?categoria=Notebooks", nil)
+	var chamados []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamados)
+	if assert.Len(t, chamados, 1) {
+		assert.Equal(t, "SN-1", chamados[0]["serial_number"])
+	}
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/por-categoria", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[
+		{"categoria": "", "total": 1},
+		{"categoria": "Impressoras", "total": 2},
+		{"categoria": "Notebooks", "total": 1}
+	]`, rec.Body.String())
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/por-categoria?id_balcao=2", nil)
+	assert.JSONEq(t, `[{"categoria": "Notebooks", "total": 1}]`, rec.Body.String())
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados/por-categoria?criado_de=ontem", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestPatchComProdutoNuloRemoveProduto(t *testing.T) {
+	router := novoRouterMemoria()
+	cadastrarCliente(router, "Ana", "529.982.247-25")
+	cadastrarBalcao(router, "joao", 0)
+	requisitar(router, http.MethodPost, "/api/v1/produtos", map[string]any{"sku": "NB-100", "nome": "Notebook 100", "categoria": "Notebooks", "id_balcao_padrao": 1})
+	requisitar(router, http.MethodPost, "/api/v1/chamados", map[string]any{"customer_id": 1, "serial_number": "SN-1", "id_produto": 1})
+
+	rec := requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", "*", map[string]any{"id_produto": 0})
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = requisitarComIfMatch(router, http.MethodPatch, "/api/v1/chamados/1", "*", map[string]any{"id_produto": nil})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var chamado map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &chamado)
+	assert.NotContains(t, chamado, "id_produto")
+
+	rec = requisitar(router, http.MethodGet, "/api/v1/chamados?categoria=Notebooks", nil)
+	assert.Equal(t, "0", rec.Header().Get("X-Total-Count"))
+}
@@ -0,0 +1,128 @@
+package migrationTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"helpdesk/migration"
+	"testing"
+	"testing/fstest"
+)
+
+func arquivos(nomes ...string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, nome := range nomes {
+		fsys["sql/"+nome] = &fstest.MapFile{Data: []byte("SELECT '" + nome + "';")}
+	}
+	return fsys
+}
+
+func TestCarregarMigracoesOrdenaPorVersao(t *testing.T) {
+	migracoes, err := migration.CarregarMigracoes(arquivos(
+		"0010_criar_atendentes.up.sql", "0010_criar_atendentes.down.sql",
+		"0002_criar_chamados.up.sql", "0002_criar_chamados.down.sql",
+	))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []migration.Migracao{
+		{Versao: 2, Nome: "criar_chamados", Up: "SELECT '0002_criar_chamados.up.sql';", Down: "SELECT '0002_criar_chamados.down.sql';"},
+		{Versao: 10, Nome: "criar_atendentes", Up: "SELECT '0010_criar_atendentes.up.sql';", Down: "SELECT '0010_criar_atendentes.down.sql';"},
+	}, migracoes)
+}
+
+func TestCarregarMigracoesInvalidas(t *testing.T) {
+	tests := []struct {
+		name          string
+		arquivos      []string
+		expectedError string
+	}{
+		{
+			name:          "Extensão desconhecida",
+			arquivos:      []string{"0001_criar_balcoes.sql"},
+			expectedError: "arquivo de migração inválido: 0001_criar_balcoes.sql",
+		},
+		{
+			name:          "Sem nome",
+			arquivos:      []string{"0001.up.sql"},
+			expectedError: "arquivo de migração sem nome: 0001.up.sql",
+		},
+		{
+			name:          "Versão não numérica",
+			arquivos:      []string{"v1_criar_balcoes.up.sql"},
+			expectedError: `versão inválida na migração v1_criar_balcoes.up.sql: strconv.ParseInt: parsing "v1": invalid syntax`,
+		},
+		{
+			name:          "Sem down",
+			arquivos:      []string{"0001_criar_balcoes.up.sql"},
+			expectedError: "migração 0001_criar_balcoes precisa de arquivos up e down",
+		},
+		{
+			name:          "Sem up",
+			arquivos:      []string{"0001_criar_balcoes.down.sql"},
+			expectedError: "migração 0001_criar_balcoes precisa de arquivos up e down",
+		},
+		{
+			name:          "Versão duplicada",
+			arquivos:      []string{"0001_criar_balcoes.up.sql", "0001_criar_chamados.up.sql"},
+			expectedError: "versão 1 duplicada: criar_balcoes e criar_chamados",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migracoes, err := migration.CarregarMigracoes(arquivos(tt.arquivos...))
+
+			assert.Nil(t, migracoes)
+			assert.EqualError(t, err, tt.expectedError)
+		})
+	}
+}
+
+func TestMigracoesEmbutidas(t *testing.T) {
+	_, err := migration.NovoMigrator(nil)
+
+	assert.NoError(t, err)
+}
+
+func TestDividirComandos(t *testing.T) {
+	tests := []struct {
+		name     string
+		script   string
+		comandos []string
+	}{
+		{
+			name:     "Comandos simples",
+			script:   "CREATE TABLE a (id INT);\n\nDROP TABLE b;\n",
+			comandos: []string{"CREATE TABLE a (id INT)", "\n\nDROP TABLE b"},
+		},
+		{
+			name:     "Último comando sem ponto e vírgula",
+			script:   "SELECT 1; SELECT 2",
+			comandos: []string{"SELECT 1", " SELECT 2"},
+		},
+		{
+			name:     "Ponto e vírgula em strings",
+			script:   `INSERT INTO t VALUES ('a;b', "c;d", 'it''s;', 'e\';f');`,
+			comandos: []string{`INSERT INTO t VALUES ('a;b', "c;d", 'it''s;', 'e\';f')`},
+		},
+		{
+			name:     "Ponto e vírgula em identificador",
+			script:   "SELECT `a;b` FROM t;",
+			comandos: []string{"SELECT `a;b` FROM t"},
+		},
+		{
+			name:     "Ponto e vírgula em comentários",
+			script:   "-- um; dois\nSELECT 1; # três; quatro\n/* cinco; seis */ SELECT 2;",
+			comandos: []string{"-- um; dois\nSELECT 1", " # três; quatro\n/* cinco; seis */ SELECT 2"},
+		},
+		{
+			name:     "Subtração não é comentário",
+			script:   "SELECT 3--1; SELECT 2;",
+			comandos: []string{"SELECT 3--1", " SELECT 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.comandos, migration.DividirComandos(tt.script))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package modelTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"helpdesk/model"
+	"testing"
+)
+
+func TestDocumentoValido(t *testing.T) {
+	tests := []struct {
+		documento string
+		esperado  bool
+	}{
+		{"529.982.247-25", true},
+		{"52998224725", true},
+		{"11.222.333/0001-81", true},
+		{"529.982.247-24", false},
+		{"11.222.333/0001-82", false},
+		{"111.111.111-11", false},
+		{"5299822472a", false},
+		{"1234", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.documento, func(t *testing.T) {
+			assert.Equal(t, tt.esperado, model.DocumentoValido(tt.documento))
+		})
+	}
+}
+
+func TestNormalizarDocumento(t *testing.T) {
+	assert.Equal(t, "11222333000181", model.NormalizarDocumento("11.222.333/0001-81"))
+}
@@ -0,0 +1,39 @@
+package modelTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"helpdesk/model"
+	"testing"
+)
+
+func TestTransicoesStatusChamado(t *testing.T) {
+	tests := []struct {
+		de       model.StatusChamado
+		para     model.StatusChamado
+		esperado bool
+	}{
+		{model.Aberto, model.EmAndamento, true},
+		{model.EmAndamento, model.Resolvido, true},
+		{model.Resolvido, model.Fechado, true},
+		{model.Resolvido, model.Aberto, true},
+		{model.Aguardando, model.Aberto, true},
+		{model.Aguardando, model.EmAndamento, false},
+		{model.Aberto, model.Resolvido, false},
+		{model.Aberto, model.Fechado, false},
+		{model.EmAndamento, model.Aberto, false},
+		{model.Fechado, model.Aberto, false},
+		{model.Aberto, "CONCLUIDO", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.de)+"->"+string(tt.para), func(t *testing.T) {
+			assert.Equal(t, tt.esperado, tt.de.PodeTransicionarPara(tt.para))
+		})
+	}
+}
+
+func TestStatusChamadoValido(t *testing.T) {
+	assert.True(t, model.EmAndamento.Valido())
+	assert.False(t, model.StatusChamado("CONCLUIDO").Valido())
+	assert.False(t, model.StatusChamado("").Valido())
+}
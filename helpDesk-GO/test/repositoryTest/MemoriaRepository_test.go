@@ -0,0 +1,274 @@
+package repositoryTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBalcaoRepositoryMemoriaSave(t *testing.T) {
+	repo := repository.NewBalcaoRepositoryMemoria(repository.NovoBancoMemoria())
+
+	salvo, err := repo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", FilaAtendimento: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), salvo.ID)
+
+	salvo.NomeAtendente = "Maria"
+	atualizado, err := repo.Save(salvo)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), atualizado.ID)
+
+	balcoes, err := repo.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, balcoes, 1)
+	assert.Equal(t, "Maria", balcoes[0].NomeAtendente)
+
+	naoEncontrado, err := repo.FindById(99)
+	assert.NoError(t, err)
+	assert.Nil(t, naoEncontrado)
+}
+
+func TestAtendenteRepositoryMemoriaLoginUnico(t *testing.T) {
+	repo := repository.NewAtendenteRepositoryMemoria(repository.NovoBancoMemoria())
+
+	joao, err := repo.Save(entity.AtendenteEntity{Atendente: model.Atendente{Login: "joao", Nome: "João", Habilidades: []string{"rede"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), joao.ID)
+
+	_, err = repo.Save(entity.AtendenteEntity{Atendente: model.Atendente{Login: "Joao", Nome: "Outro"}})
+	assert.ErrorIs(t, err, repository.ErrRegistroDuplicado)
+
+	joao.Nome = "João Silva"
+	_, err = repo.Save(joao)
+	assert.NoError(t, err)
+
+	encontrado, err := repo.FindByLogin("JOAO")
+	assert.NoError(t, err)
+	assert.Equal(t, "João Silva", encontrado.Nome)
+	assert.Equal(t, []string{"rede"}, encontrado.Habilidades)
+}
+
+func TestBalcaoRepositoryMemoriaUmBalcaoPorAtendente(t *testing.T) {
+	repo := repository.NewBalcaoRepositoryMemoria(repository.NovoBancoMemoria())
+
+	balcao, err := repo.Save(entity.BalcaoEntity{Balcao: model.Balcao{IDAtendente: 1}})
+	assert.NoError(t, err)
+
+	_, err = repo.Save(entity.BalcaoEntity{Balcao: model.Balcao{IDAtendente: 1}})
+	assert.ErrorIs(t, err, repository.ErrRegistroDuplicado)
+
+	_, err = repo.Save(balcao)
+	assert.NoError(t, err)
+
+	encontrado, err := repo.FindByAtendente(1)
+	assert.NoError(t, err)
+	assert.Equal(t, balcao.ID, encontrado.ID)
+
+	semBalcao, err := repo.FindByAtendente(2)
+	assert.NoError(t, err)
+	assert.Nil(t, semBalcao)
+}
+
+func TestBalcaoRepositoryMemoriaReservarVagaExigeBalcaoAtivo(t *testing.T) {
+	repo := repository.NewBalcaoRepositoryMemoria(repository.NovoBancoMemoria())
+
+	balcao, _ := repo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Capacidade: 2, Ativo: true}})
+
+	reservou, err := repo.ReservarVaga(balcao.ID, 5)
+	assert.NoError(t, err)
+	assert.True(t, reservou)
+
+	// A fila andar não muda a versão, então o Save lido antes da reserva vale.
+	balcao.Ativo = false
+	_, err = repo.Save(balcao)
+	assert.NoError(t, err)
+
+	reservou, err = repo.ReservarVaga(balcao.ID, 5)
+	assert.NoError(t, err)
+	assert.False(t, reservou)
+
+	inativo, _ := repo.FindById(balcao.ID)
+	assert.Equal(t, 1, inativo.FilaAtendimento)
+}
+
+func TestChamadoRepositoryMemoriaConfereVersao(t *testing.T) {
+	repo := repository.NewChamadoRepositoryMemoria(repository.NovoBancoMemoria())
+
+	chamado, err := repo.Save(&entity.ChamadoEntity{Chamado: model.Chamado{SerialNumber: "SN-1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), chamado.Versao)
+
+	lido, _ := repo.FindById(chamado.ID)
+	outraLeitura, _ := repo.FindById(chamado.ID)
+
+	lido.Motivo = "Primeira edição"
+	salvo, err := repo.Save(lido)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), salvo.Versao)
+
+	outraLeitura.Motivo = "Edição atrasada"
+	_, err = repo.Save(outraLeitura)
+	assert.ErrorIs(t, err, repository.ErrVersaoDesatualizada)
+
+	atual, _ := repo.FindById(chamado.ID)
+	assert.Equal(t, "Primeira edição", atual.Motivo)
+}
+
+func TestChamadoRepositoryMemoriaPreencheBalcao(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	balcoes := repository.NewBalcaoRepositoryMemoria(banco)
+	chamados := repository.NewChamadoRepositoryMemoria(banco)
+
+	balcao, _ := balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João"}})
+	chamado, err := chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{
+		CustomerID:    7,
+		SerialNumber:  "SN-1",
+		StatusChamado: "ABERTO",
+		IDBalcao:      balcao.ID,
+	}})
+	assert.NoError(t, err)
+
+	encontrado, err := chamados.FindById(chamado.ID)
+	assert.NoError(t, err)
+	if assert.NotNil(t, encontrado.Balcao) {
+		assert.Equal(t, "João", encontrado.Balcao.NomeAtendente)
+	}
+
+	porCliente, err := balcoes.FindByCustomerId(7)
+	assert.NoError(t, err)
+	assert.Len(t, porCliente, 1)
+
+	abertos, err := chamados.FindByBalcaoAndStatus(balcao, model.Aberto)
+	assert.NoError(t, err)
+	assert.Len(t, abertos, 1)
+}
+
+func TestChamadoRepositoryMemoriaFindBySerial(t *testing.T) {
+	chamados := repository.NewChamadoRepositoryMemoria(repository.NovoBancoMemoria())
+	agora := time.Now()
+
+	chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{SerialNumber: "SN-1", DataCreation: agora.Add(-time.Hour), Motivo: "antigo"}})
+	chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{SerialNumber: "SN-1", DataCreation: agora, Motivo: "recente"}})
+
+	chamado, err := chamados.FindBySerial("SN-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "recente", chamado.Motivo)
+
+	inexistente, err := chamados.FindBySerial("SN-2")
+	assert.NoError(t, err)
+	assert.Nil(t, inexistente)
+}
+
+func TestChamadoRepositoryMemoriaFindAllPaginated(t *testing.T) {
+	chamados := repository.NewChamadoRepositoryMemoria(repository.NovoBancoMemoria())
+	for i := 0; i < 5; i++ {
+		chamados.Save(&entity.ChamadoEntity{})
+	}
+
+	pagina, err := chamados.FindAllPaginated(1, 2)
+	assert.NoError(t, err)
+	if assert.Len(t, pagina, 2) {
+		assert.Equal(t, int64(3), pagina[0].ID)
+		assert.Equal(t, int64(4), pagina[1].ID)
+	}
+
+	vazia, err := chamados.FindAllPaginated(3, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, vazia)
+}
+
+func TestAtendimentoRepositoryMemoriaFindOpenByBalcao(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	chamados := repository.NewChamadoRepositoryMemoria(banco)
+	atendimentos := repository.NewAtendimentoRepositoryMemoria(banco)
+	balcao := &entity.BalcaoEntity{Balcao: model.Balcao{ID: 1}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			chamado, _ := chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{IDBalcao: 1, StatusChamado: model.Aberto}})
+			assert.NoError(t, atendimentos.Save(&entity.ListaAtendimento{Chamado: chamado, Balcao: balcao}))
+		}()
+	}
+	wg.Wait()
+
+	abertos, err := atendimentos.FindOpenByBalcao(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), abertos)
+
+	resolvido, _ := chamados.FindById(1)
+	resolvido.StatusChamado = model.Resolvido
+	chamados.Save(resolvido)
+
+	abertos, err = atendimentos.FindOpenByBalcao(1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(19), abertos)
+
+	outroBalcao, err := atendimentos.FindOpenByBalcao(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), outroBalcao)
+}
+
+func TestAtendimentoRepositoryMemoriaFindAbertoByChamado(t *testing.T) {
+	atendimentos := repository.NewAtendimentoRepositoryMemoria(repository.NovoBancoMemoria())
+	chamado := &entity.ChamadoEntity{Chamado: model.Chamado{ID: 7, IDBalcao: 1, StatusChamado: model.Aberto}}
+	balcao := &entity.BalcaoEntity{Balcao: model.Balcao{ID: 1}}
+
+	entrada := time.Now().Add(-time.Hour)
+	atendimento := &entity.ListaAtendimento{Chamado: chamado, Balcao: balcao, DataEntrada: entrada}
+	assert.NoError(t, atendimentos.Save(atendimento))
+	assert.Equal(t, int64(1), atendimento.ID)
+
+	aberto, err := atendimentos.FindAbertoByChamado(7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), aberto.ID)
+	assert.Equal(t, int64(1), aberto.Balcao.ID)
+	assert.True(t, aberto.DataEntrada.Equal(entrada))
+	assert.True(t, aberto.DataInicio.IsZero())
+
+	aberto.DataFim = time.Now()
+	assert.NoError(t, atendimentos.Save(aberto))
+	assert.Equal(t, int64(1), aberto.ID)
+
+	finalizado, err := atendimentos.FindAbertoByChamado(7)
+	assert.NoError(t, err)
+	assert.Nil(t, finalizado)
+}
+
+func TestChamadoRepositoryMemoriaBuscarChamados(t *testing.T) {
+	chamados := repository.NewChamadoRepositoryMemoria(repository.NovoBancoMemoria())
+	inicio := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	for i, produto := range []string{"Notebook", "Celular", "Notebook", "Notebook", "Tablet"} {
+		chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{
+			Produto:       produto,
+			StatusChamado: model.Aberto,
+			DataCreation:  inicio.AddDate(0, 0, i),
+		}})
+	}
+
+	filtro := model.FiltroChamados{Produto: "Notebook", CriadoAte: inicio.AddDate(0, 0, 3)}
+	total, err := chamados.ContarChamados(filtro)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	ordem := model.OrdemChamados{Campo: model.OrdenarPorDataCreation, Decrescente: true}
+	encontrados, err := chamados.BuscarChamados(model.ConsultaChamados{Filtro: model.FiltroChamados{Produto: "Notebook"}, Ordem: ordem, Limite: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, encontrados, 2) {
+		assert.Equal(t, int64(4), encontrados[0].ID)
+		assert.Equal(t, int64(3), encontrados[1].ID)
+	}
+
+	apos := model.PosicaoChamado{Valor: encontrados[1].DataCreation, ID: encontrados[1].ID}
+	restantes, err := chamados.BuscarChamados(model.ConsultaChamados{Filtro: model.FiltroChamados{Produto: "Notebook"}, Ordem: ordem, Apos: &apos, Limite: 2})
+	assert.NoError(t, err)
+	if assert.Len(t, restantes, 1) {
+		assert.Equal(t, int64(1), restantes[0].ID)
+	}
+}
@@ -0,0 +1,67 @@
+package repositoryTest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"testing"
+)
+
+func TestUnidadeDeTrabalhoMemoriaConfirma(t *testing.T) {
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+	balcao, _ := unidade.Repositorios().Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Capacidade: 1, Ativo: true}})
+
+	err := unidade.Executar(func(repos repository.Repositorios) error {
+		if _, err := repos.Balcoes.ReservarVaga(balcao.ID, 5); err != nil {
+			return err
+		}
+		_, err := repos.Chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{IDBalcao: balcao.ID, StatusChamado: model.Aberto}})
+		return err
+	})
+	assert.NoError(t, err)
+
+	ocupado, _ := unidade.Repositorios().Balcoes.FindById(balcao.ID)
+	assert.Equal(t, 1, ocupado.FilaAtendimento)
+	chamados, _ := unidade.Repositorios().Chamados.FindAll()
+	assert.Len(t, chamados, 1)
+}
+
+func TestUnidadeDeTrabalhoMemoriaDesfazEmErro(t *testing.T) {
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+	balcao, _ := unidade.Repositorios().Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Capacidade: 1, Ativo: true}})
+
+	falha := errors.New("falha ao registrar atendimento")
+	err := unidade.Executar(func(repos repository.Repositorios) error {
+		repos.Balcoes.ReservarVaga(balcao.ID, 5)
+		repos.Chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{IDBalcao: balcao.ID, StatusChamado: model.Aberto}})
+		return falha
+	})
+	assert.ErrorIs(t, err, falha)
+
+	livre, _ := unidade.Repositorios().Balcoes.FindById(balcao.ID)
+	assert.Equal(t, 0, livre.FilaAtendimento)
+	chamados, _ := unidade.Repositorios().Chamados.FindAll()
+	assert.Empty(t, chamados)
+
+	novo, _ := unidade.Repositorios().Chamados.Save(&entity.ChamadoEntity{})
+	assert.Equal(t, int64(1), novo.ID)
+}
+
+func TestUnidadeDeTrabalhoMemoriaDesfazEmPanico(t *testing.T) {
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+
+	assert.Panics(t, func() {
+		unidade.Executar(func(repos repository.Repositorios) error {
+			repos.Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João"}})
+			panic("erro inesperado")
+		})
+	})
+
+	balcoes, _ := unidade.Repositorios().Balcoes.FindAll()
+	assert.Empty(t, balcoes)
+
+	_, err := unidade.Repositorios().Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "Maria"}})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+package serverTest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"helpdesk/Exception"
+	"helpdesk/server"
+	"net/http"
+	"testing"
+)
+
+func TestNovoProblema(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCodigo string
+		expectedDetail string
+	}{
+		{
+			name:           "Não encontrado",
+			err:            &Exception.NotFoundException{Recurso: "Chamado", ID: 3},
+			expectedStatus: http.StatusNotFound,
+			expectedCodigo: Exception.CodigoNaoEncontrado,
+			expectedDetail: "Chamado com ID 3 não foi encontrado",
+		},
+		{
+			name:           "Conflito com código específico",
+			err:            &Exception.ConflictException{Message: "Serial em uso.", Codigo: Exception.CodigoSerialEmUso},
+			expectedStatus: http.StatusConflict,
+			expectedCodigo: Exception.CodigoSerialEmUso,
+			expectedDetail: "Serial em uso.",
+		},
+		{
+			name:           "Proibido",
+			err:            &Exception.ForbiddenException{Message: "Sem permissão."},
+			expectedStatus: http.StatusForbidden,
+			expectedCodigo: Exception.CodigoProibido,
+			expectedDetail: "Sem permissão.",
+		},
+		{
+			name:           "Validação",
+			err:            &Exception.ValidationException{Message: "Dados inválidos."},
+			expectedStatus: http.StatusBadRequest,
+			expectedCodigo: Exception.CodigoValidacao,
+			expectedDetail: "Dados inválidos.",
+		},
+		{
+			name:           "Capacidade excedida",
+			err:            &Exception.CapacidadeExcedidaException{BalcaoID: 1, Capacidade: 1, Abertos: 2},
+			expectedStatus: http.StatusConflict,
+			expectedCodigo: Exception.CodigoCapacidadeExcedida,
+			expectedDetail: "A capacidade 1 é menor que os 2 atendimentos em aberto no balcão 1.",
+		},
+		{
+			name:           "Transição inválida embrulhada",
+			err:            fmt.Errorf("ao editar: %w", &Exception.TransicaoInvalidaException{De: "FECHADO", Para: "ABERTO"}),
+			expectedStatus: http.StatusConflict,
+			expectedCodigo: Exception.CodigoTransicaoInvalida,
+			expectedDetail: "Transição de status inválida: FECHADO -> ABERTO",
+		},
+		{
+			name:           "Erro desconhecido não vaza a mensagem",
+			err:            errors.New("dial tcp 10.0.0.1:3306: connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCodigo: Exception.CodigoErroInterno,
+			expectedDetail: "Erro interno do servidor.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problema := server.NovoProblema(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, problema.Status)
+			assert.Equal(t, http.StatusText(tt.expectedStatus), problema.Title)
+			assert.Equal(t, tt.expectedCodigo, problema.Codigo)
+			assert.Equal(t, tt.expectedDetail, problema.Detail)
+		})
+	}
+}
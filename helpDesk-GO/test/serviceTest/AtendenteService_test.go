@@ -0,0 +1,158 @@
+package serviceTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"testing"
+)
+
+type MockAtendenteRepository struct {
+	mock.Mock
+}
+
+func (m *MockAtendenteRepository) FindAll() ([]entity.AtendenteEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.AtendenteEntity), args.Error(1)
+}
+
+func (m *MockAtendenteRepository) FindById(id int64) (*entity.AtendenteEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AtendenteEntity), args.Error(1)
+}
+
+func (m *MockAtendenteRepository) FindByLogin(login string) (*entity.AtendenteEntity, error) {
+	args := m.Called(login)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.AtendenteEntity), args.Error(1)
+}
+
+func (m *MockAtendenteRepository) Save(atendente entity.AtendenteEntity) (entity.AtendenteEntity, error) {
+	args := m.Called(atendente)
+	return args.Get(0).(entity.AtendenteEntity), args.Error(1)
+}
+
+// cadastrarAtendentes grava os atendentes ativos joao e maria e devolve
+// seus IDs.
+func cadastrarAtendentes(repo repository.AtendenteRepository) (joao, maria int64) {
+	a, _ := repo.Save(entity.AtendenteEntity{Atendente: model.Atendente{Login: "joao", Nome: "João", Ativo: true}})
+	b, _ := repo.Save(entity.AtendenteEntity{Atendente: model.Atendente{Login: "maria", Nome: "Maria", Ativo: true}})
+	return a.ID, b.ID
+}
+
+func TestCadastrarAtendente(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	as := service.NovoAtendenteService(
+		repository.NewAtendenteRepositoryMemoria(banco),
+		repository.NewBalcaoRepositoryMemoria(banco),
+		repository.NewChamadoRepositoryMemoria(banco),
+	)
+
+	atendente, err := as.CadastrarAtendente(&dto.CriarAtendenteDTO{Login: "joao", Nome: "João", Habilidades: []string{"rede", "", "Rede"}})
+	assert.NoError(t, err)
+	assert.True(t, atendente.Ativo)
+	assert.Equal(t, []string{"rede"}, atendente.Habilidades)
+
+	_, err = as.CadastrarAtendente(&dto.CriarAtendenteDTO{Login: "joao", Nome: "Outro"})
+	var conflito *Exception.ConflictException
+	if assert.ErrorAs(t, err, &conflito) {
+		assert.Equal(t, Exception.CodigoAtendenteDuplicado, conflito.CodigoErro())
+	}
+}
+
+func TestCadastrarAtendenteUsaChaveUnicaDoRepositorio(t *testing.T) {
+	mockRepo := new(MockAtendenteRepository)
+	as := service.NovoAtendenteService(mockRepo, nil, nil)
+
+	mockRepo.On("FindByLogin", "joao").Return(nil, nil)
+	mockRepo.On("Save", mock.Anything).Return(entity.AtendenteEntity{}, repository.ErrRegistroDuplicado)
+
+	result, err := as.CadastrarAtendente(&dto.CriarAtendenteDTO{Login: "joao", Nome: "João"})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "Conflict: Já existe um atendente com o login joao.")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestDesativarAtendenteComChamadoEmAndamento(t *testing.T) {
+	mockRepo := new(MockAtendenteRepository)
+	mockChamadoRepo := new(MockChamadoRepository)
+	as := service.NovoAtendenteService(mockRepo, nil, mockChamadoRepo)
+
+	mockRepo.On("FindById", int64(1)).Return(&entity.AtendenteEntity{Atendente: model.Atendente{ID: 1, Login: "joao", Ativo: true}}, nil)
+	mockChamadoRepo.On("FindByAtendenteAndEstado", int64(1), model.EmAndamento).Return([]entity.ChamadoEntity{{Chamado: model.Chamado{ID: 5}}}, nil)
+
+	err := as.DesativarAtendente(1)
+
+	assert.EqualError(t, err, "Conflict: O atendente joao ainda tem 1 chamado(s) em andamento.")
+	mockRepo.AssertExpectations(t)
+	mockChamadoRepo.AssertExpectations(t)
+}
+
+func TestDesativarAtendenteComBalcao(t *testing.T) {
+	mockRepo := new(MockAtendenteRepository)
+	mockBalcaoRepo := new(MockBalcaoRepository)
+	mockChamadoRepo := new(MockChamadoRepository)
+	as := service.NovoAtendenteService(mockRepo, mockBalcaoRepo, mockChamadoRepo)
+
+	mockRepo.On("FindById", int64(1)).Return(&entity.AtendenteEntity{Atendente: model.Atendente{ID: 1, Login: "joao", Ativo: true}}, nil)
+	mockChamadoRepo.On("FindByAtendenteAndEstado", int64(1), model.EmAndamento).Return([]entity.ChamadoEntity{}, nil)
+	mockBalcaoRepo.On("FindByAtendente", int64(1)).Return(&entity.BalcaoEntity{Balcao: model.Balcao{ID: 3, IDAtendente: 1}}, nil)
+
+	err := as.DesativarAtendente(1)
+
+	var conflito *Exception.ConflictException
+	assert.ErrorAs(t, err, &conflito)
+	assert.Equal(t, Exception.CodigoAtendenteComBalcao, conflito.Codigo)
+	assert.Equal(t, "/api/v1/balcoes/3", conflito.Uri)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything)
+	mockBalcaoRepo.AssertExpectations(t)
+}
+
+// balcoesSemGravacao simula um Save de balcão que perde a corrida de versão.
+type balcoesSemGravacao struct {
+	repository.BalcaoRepository
+}
+
+func (balcoesSemGravacao) Save(entity.BalcaoEntity) (entity.BalcaoEntity, error) {
+	return entity.BalcaoEntity{}, repository.ErrVersaoDesatualizada
+}
+
+type unidadeSemGravarBalcao struct {
+	repository.UnidadeDeTrabalho
+}
+
+func (u unidadeSemGravarBalcao) Executar(fn func(repos repository.Repositorios) error) error {
+	return u.UnidadeDeTrabalho.Executar(func(repos repository.Repositorios) error {
+		repos.Balcoes = balcoesSemGravacao{repos.Balcoes}
+		return fn(repos)
+	})
+}
+
+func TestEditarAtendenteDesfazRenomeacaoSeBalcaoFalhar(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(banco)
+	repos := unidade.Repositorios()
+	joao, _ := cadastrarAtendentes(repos.Atendentes)
+	repos.Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{IDAtendente: joao, NomeAtendente: "João", Ativo: true}})
+
+	as := service.NovoAtendenteServiceTransacional(unidadeSemGravarBalcao{unidade})
+	_, err := as.EditarAtendente(joao, &dto.EditarAtendenteDTO{Nome: "João Silva"})
+	var versao *Exception.VersaoDesatualizadaException
+	assert.ErrorAs(t, err, &versao)
+
+	atendente, _ := repos.Atendentes.FindById(joao)
+	assert.Equal(t, "João", atendente.Nome)
+	balcao, _ := repos.Balcoes.FindByAtendente(joao)
+	assert.Equal(t, "João", balcao.NomeAtendente)
+}
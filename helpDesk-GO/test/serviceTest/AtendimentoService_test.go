@@ -14,7 +14,15 @@ func (m *MockAtendimentoRepository) Save(atendimento *entity.ListaAtendimento) e
 	return args.Error(0)
 }
 
-func (m *MockAtendimentoRepository) FindOpenByBalcao(balcaoID int) (int, error) {
+func (m *MockAtendimentoRepository) FindAbertoByChamado(chamadoID int64) (*entity.ListaAtendimento, error) {
+	args := m.Called(chamadoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ListaAtendimento), args.Error(1)
+}
+
+func (m *MockAtendimentoRepository) FindOpenByBalcao(balcaoID int64) (int64, error) {
 	args := m.Called(balcaoID)
-	return args.Int(0), args.Error(1)
+	return args.Get(0).(int64), args.Error(1)
 }
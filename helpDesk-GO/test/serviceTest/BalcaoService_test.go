@@ -0,0 +1,381 @@
+package serviceTest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"testing"
+	"time"
+)
+
+type MockBalcaoRepository struct {
+	mock.Mock
+}
+
+func (m *MockBalcaoRepository) Save(balcao entity.BalcaoEntity) (entity.BalcaoEntity, error) {
+	args := m.Called(balcao)
+	return args.Get(0).(entity.BalcaoEntity), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) FindByAtendente(idAtendente int64) (*entity.BalcaoEntity, error) {
+	args := m.Called(idAtendente)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BalcaoEntity), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) FindById(id int64) (*entity.BalcaoEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1) // Retorna erro se o primeiro argumento for nil
+	}
+	return args.Get(0).(*entity.BalcaoEntity), args.Error(1) // Retorna o ponteiro corretamente
+}
+
+func (m *MockBalcaoRepository) FindByIdForUpdate(id int64) (*entity.BalcaoEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.BalcaoEntity), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) FindAll() ([]entity.BalcaoEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.BalcaoEntity), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) FindByCustomerId(customerId int64) ([]entity.BalcaoEntity, error) {
+	args := m.Called(customerId)
+	return args.Get(0).([]entity.BalcaoEntity), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) ReservarVaga(id int64, capacidadePadrao int) (bool, error) {
+	args := m.Called(id, capacidadePadrao)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) LiberarVaga(id int64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestCadastrarBalcao(t *testing.T) {
+	tests := []struct {
+		name          string
+		balcaoDTO     *dto.CriarBalcaoDTO
+		atendente     *entity.AtendenteEntity
+		balcaoDoAtend *entity.BalcaoEntity
+		erroSave      error
+		expectedError string
+	}{
+		{
+			name:          "BalcaoDTO é nil",
+			balcaoDTO:     nil,
+			expectedError: "O balcão nao pode ser nulo!",
+		},
+		{
+			name:          "Atendente inexistente",
+			balcaoDTO:     &dto.CriarBalcaoDTO{IDAtendente: 9},
+			expectedError: "Atendente com ID 9 não foi encontrado",
+		},
+		{
+			name:          "Atendente inativo",
+			balcaoDTO:     &dto.CriarBalcaoDTO{IDAtendente: 1},
+			atendente:     &entity.AtendenteEntity{Atendente: model.Atendente{ID: 1, Login: "joao"}},
+			expectedError: "Conflict: O atendente joao está inativo.",
+		},
+		{
+			name:          "Atendente já possui balcão",
+			balcaoDTO:     &dto.CriarBalcaoDTO{IDAtendente: 1, Capacidade: 5},
+			atendente:     &entity.AtendenteEntity{Atendente: model.Atendente{ID: 1, Login: "joao", Ativo: true}},
+			balcaoDoAtend: &entity.BalcaoEntity{Balcao: model.Balcao{ID: 4, IDAtendente: 1}},
+			expectedError: "Conflict: O atendente joao já possui um balcão.",
+		},
+		{
+			name:          "Chave única do repositório",
+			balcaoDTO:     &dto.CriarBalcaoDTO{IDAtendente: 1},
+			atendente:     &entity.AtendenteEntity{Atendente: model.Atendente{ID: 1, Login: "joao", Ativo: true}},
+			erroSave:      repository.ErrRegistroDuplicado,
+			expectedError: "Conflict: O atendente joao já possui um balcão.",
+		},
+		{
+			name:      "Cadastro bem-sucedido",
+			balcaoDTO: &dto.CriarBalcaoDTO{IDAtendente: 2, Capacidade: 10},
+			atendente: &entity.AtendenteEntity{Atendente: model.Atendente{ID: 2, Login: "maria", Nome: "Maria", Ativo: true}},
+		},
+		{
+			name:          "Erro ao salvar balcão",
+			balcaoDTO:     &dto.CriarBalcaoDTO{IDAtendente: 3, Capacidade: 3},
+			atendente:     &entity.AtendenteEntity{Atendente: model.Atendente{ID: 3, Login: "carlos", Ativo: true}},
+			erroSave:      errors.New("Erro ao salvar o balcão"),
+			expectedError: "Erro ao salvar o balcão",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBalcaoRepository)
+			mockAtendenteRepo := new(MockAtendenteRepository)
+			cs := service.BalcaoService{
+				BalcaoRepository:    mockRepo,
+				AtendenteRepository: mockAtendenteRepo,
+			}
+
+			if tt.balcaoDTO != nil {
+				mockAtendenteRepo.On("FindById", tt.balcaoDTO.IDAtendente).Return(tt.atendente, nil)
+			}
+			if tt.atendente != nil && tt.atendente.Ativo {
+				mockRepo.On("FindByAtendente", tt.atendente.ID).Return(tt.balcaoDoAtend, nil)
+				if tt.balcaoDoAtend == nil {
+					mockRepo.On("Save", mock.MatchedBy(func(b entity.BalcaoEntity) bool {
+						return b.IDAtendente == tt.atendente.ID && b.NomeAtendente == tt.atendente.Nome && b.Ativo
+					})).Return(entity.BalcaoEntity{}, tt.erroSave)
+				}
+			}
+
+			result, err := cs.CadastrarBalcao(tt.balcaoDTO)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NotNil(t, result)
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+			mockAtendenteRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEditarBalcaoTrocaAtendente(t *testing.T) {
+	mockRepo := new(MockBalcaoRepository)
+	mockAtendenteRepo := new(MockAtendenteRepository)
+	bs := service.BalcaoService{BalcaoRepository: mockRepo, AtendenteRepository: mockAtendenteRepo}
+
+	mockRepo.On("FindById", int64(1)).Return(&entity.BalcaoEntity{
+		Balcao: model.Balcao{ID: 1, IDAtendente: 7, NomeAtendente: "Carlos", Versao: 2},
+	}, nil)
+	mockAtendenteRepo.On("FindById", int64(8)).Return(&entity.AtendenteEntity{
+		Atendente: model.Atendente{ID: 8, Login: "ana", Nome: "Ana", Ativo: true},
+	}, nil)
+	mockRepo.On("FindByAtendente", int64(8)).Return(nil, nil)
+	mockRepo.On("Save", mock.MatchedBy(func(b entity.BalcaoEntity) bool {
+		return b.IDAtendente == 8 && b.NomeAtendente == "Ana"
+	})).Return(entity.BalcaoEntity{Balcao: model.Balcao{ID: 1, IDAtendente: 8, NomeAtendente: "Ana", Versao: 3}}, nil)
+
+	result, err := bs.EditarBalcao(&dto.EditarBalcaoDTO{IDAtendente: 8}, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana", result.NomeAtendente)
+	mockRepo.AssertExpectations(t)
+	mockAtendenteRepo.AssertExpectations(t)
+}
+
+func TestListarBalcoes(t *testing.T) {
+	mockRepo := new(MockBalcaoRepository)
+	cs := service.BalcaoService{
+		BalcaoRepository: mockRepo,
+	}
+
+	mockRepo.On("FindAll").Return([]entity.BalcaoEntity{
+		{Balcao: model.Balcao{NomeAtendente: "João"}},
+	}, nil)
+
+	result, err := cs.ListarBalcoes()
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, "João", result[0].NomeAtendente)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestEditarBalcao(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            int64
+		balcaoDTO     *dto.EditarBalcaoDTO
+		buscaBalcao   bool
+		mockFindById  *entity.BalcaoEntity
+		expectedError string
+	}{
+		{
+			name:          "DTO é nulo",
+			balcaoDTO:     nil,
+			expectedError: "Balcão ou ID não podem ser nulos",
+		},
+		{
+			name: "Erro ao encontrar o balcão",
+			id:   2,
+			balcaoDTO: &dto.EditarBalcaoDTO{
+				IDAtendente: 7,
+			},
+			expectedError: "Balcão com ID 2 não foi encontrado",
+			buscaBalcao:   true,
+			mockFindById:  nil,
+		},
+		{
+			name: "Edição bem-sucedida",
+			id:   1,
+			balcaoDTO: &dto.EditarBalcaoDTO{
+				IDAtendente: 7,
+			},
+			expectedError: "",
+			buscaBalcao:   true,
+			mockFindById: &entity.BalcaoEntity{
+				Balcao: model.Balcao{
+					ID:              1,
+					IDAtendente:     7,
+					NomeAtendente:   "Carlos",
+					FilaAtendimento: 3,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBalcaoRepository)
+			cs := service.BalcaoService{
+				BalcaoRepository: mockRepo,
+			}
+
+			if tt.buscaBalcao && tt.mockFindById == nil {
+				mockRepo.On("FindById", tt.id).Return(nil, nil) // Balcão inexistente
+			} else if tt.buscaBalcao {
+				mockRepo.On("FindById", tt.id).Return(tt.mockFindById, nil) // Retorna o ponteiro corretamente
+			}
+
+			if tt.expectedError == "" {
+				mockRepo.On("Save", mock.Anything).Return(entity.BalcaoEntity{}, nil)
+			}
+
+			result, err := cs.EditarBalcao(tt.balcaoDTO, tt.id)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NotNil(t, result)
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEditarBalcaoCapacidade(t *testing.T) {
+	tests := []struct {
+		name          string
+		capacidade    int
+		abertos       int
+		expectedError string
+	}{
+		{
+			name:       "Capacidade aumentada",
+			capacidade: 8,
+			abertos:    3,
+		},
+		{
+			name:       "Capacidade igual aos atendimentos em aberto",
+			capacidade: 3,
+			abertos:    3,
+		},
+		{
+			name:          "Capacidade menor que os atendimentos em aberto",
+			capacidade:    2,
+			abertos:       3,
+			expectedError: "A capacidade 2 é menor que os 3 atendimentos em aberto no balcão 1.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBalcaoRepository)
+			bs := service.NovoBalcaoService(mockRepo, new(MockChamadoRepository), new(MockAtendenteRepository))
+
+			mockRepo.On("FindById", int64(1)).Return(&entity.BalcaoEntity{
+				Balcao: model.Balcao{ID: 1, IDAtendente: 7, NomeAtendente: "Carlos", FilaAtendimento: tt.abertos, Capacidade: 5},
+			}, nil)
+			if tt.expectedError == "" {
+				mockRepo.On("Save", mock.Anything).Return(entity.BalcaoEntity{
+					Balcao: model.Balcao{ID: 1, NomeAtendente: "Carlos", FilaAtendimento: 3, Capacidade: tt.capacidade},
+				}, nil)
+			}
+
+			result, err := bs.EditarBalcao(&dto.EditarBalcaoDTO{IDAtendente: 7, Capacidade: tt.capacidade}, 1)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.capacidade, result.Capacidade)
+				assert.Equal(t, 3, result.FilaAtendimento)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDesativarBalcaoTravaOBalcao(t *testing.T) {
+	mockRepo := new(MockBalcaoRepository)
+	mockChamadoRepo := new(MockChamadoRepository)
+	bs := service.NovoBalcaoService(mockRepo, mockChamadoRepo, new(MockAtendenteRepository))
+
+	mockRepo.On("FindByIdForUpdate", int64(1)).Return(&entity.BalcaoEntity{
+		Balcao: model.Balcao{ID: 1, IDAtendente: 7, Ativo: true, Versao: 3},
+	}, nil)
+	mockChamadoRepo.On("ContarChamados", mock.Anything).Return(int64(0), nil)
+	mockRepo.On("Save", mock.MatchedBy(func(balcao entity.BalcaoEntity) bool {
+		return !balcao.Ativo && balcao.Versao == 3
+	})).Return(entity.BalcaoEntity{}, nil)
+
+	assert.NoError(t, bs.DesativarBalcao(1))
+
+	mockRepo.AssertNotCalled(t, "FindById", mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+// Atendimentos encerrados continuam na lista_atendimento; a capacidade é
+// conferida pela fila do balcão, que só conta as vagas ocupadas.
+func TestEditarBalcaoCapacidadeIgnoraAtendimentosEncerrados(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(banco)
+	repos := unidade.Repositorios()
+	joao, _ := cadastrarAtendentes(repos.Atendentes)
+	balcao, _ := repos.Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{IDAtendente: joao, NomeAtendente: "João", Capacidade: 5, Ativo: true}})
+	for i := 0; i < 3; i++ {
+		repos.Balcoes.ReservarVaga(balcao.ID, 5)
+		chamado, _ := repos.Chamados.Save(&entity.ChamadoEntity{Chamado: model.Chamado{IDBalcao: balcao.ID, StatusChamado: model.Aberto}})
+		atendimento := &entity.ListaAtendimento{Chamado: chamado, Balcao: &balcao, DataEntrada: time.Now()}
+		if i > 0 {
+			atendimento.DataFim = time.Now()
+			chamado.StatusChamado = model.Resolvido
+			repos.Chamados.Save(chamado)
+			repos.Balcoes.LiberarVaga(balcao.ID)
+		}
+		repos.Atendimentos.Save(atendimento)
+	}
+	// Um dos chamados encerrados aqui foi reaberto, e ocupa vaga, em outro balcão.
+	reaberto, _ := repos.Chamados.FindById(3)
+	reaberto.IDBalcao = 99
+	reaberto.StatusChamado = model.Aberto
+	repos.Chamados.Save(reaberto)
+
+	bs := service.NovoBalcaoServiceTransacional(unidade)
+
+	editado, err := bs.EditarBalcao(&dto.EditarBalcaoDTO{IDAtendente: joao, Capacidade: 1}, balcao.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, editado.Capacidade)
+}
@@ -0,0 +1,521 @@
+package serviceTest
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"sync"
+	"testing"
+)
+
+type MockChamadoRepository struct {
+	mock.Mock
+}
+
+func (m *MockChamadoRepository) Save(chamado *entity.ChamadoEntity) (*entity.ChamadoEntity, error) {
+	args := m.Called(chamado)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindAll() ([]entity.ChamadoEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindById(id int64) (*entity.ChamadoEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindByCustomerId(customerId int64) ([]entity.ChamadoEntity, error) {
+	args := m.Called(customerId)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindBySerial(serial string) (*entity.ChamadoEntity, error) {
+	args := m.Called(serial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) ContarPorCategoria(filtro model.FiltroChamados) ([]model.TotalCategoria, error) {
+	args := m.Called(filtro)
+	return args.Get(0).([]model.TotalCategoria), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindHistoricoBySerial(serial string) ([]entity.ChamadoEntity, error) {
+	args := m.Called(serial)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindByAtendenteAndEstado(idAtendente int64, status model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	args := m.Called(idAtendente, status)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindByBalcaoAndStatus(balcao entity.BalcaoEntity, status model.StatusChamado) ([]entity.ChamadoEntity, error) {
+	args := m.Called(balcao, status)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) FindAllPaginated(page int, size int) ([]entity.ChamadoEntity, error) {
+	args := m.Called(page, size)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) BuscarChamados(consulta model.ConsultaChamados) ([]entity.ChamadoEntity, error) {
+	args := m.Called(consulta)
+	return args.Get(0).([]entity.ChamadoEntity), args.Error(1)
+}
+
+func (m *MockChamadoRepository) ContarChamados(filtro model.FiltroChamados) (int64, error) {
+	args := m.Called(filtro)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockBalcaoRepository) FindByID(id int) (*entity.BalcaoEntity, error) {
+	args := m.Called(id)
+	return args.Get(0).(*entity.BalcaoEntity), args.Error(1)
+}
+
+func TestCriarChamado(t *testing.T) {
+	tests := []struct {
+		name            string
+		chamadoDTO      *dto.CriarChamadoDTO
+		mockSetup       func(*MockChamadoRepository, *MockBalcaoRepository, *MockAtendimentoRepository)
+		expectedError   string
+		expectedPosicao int
+	}{
+		{
+			name:       "ChamadoDTO é nulo",
+			chamadoDTO: nil,
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+			},
+			expectedError: "Chamado não pode ser nulo.",
+		},
+		{
+			name:       "Balcão não encontrado",
+			chamadoDTO: &dto.CriarChamadoDTO{IDBalcao: 1},
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+				chamadoRepo.On("FindBySerial", "").Return(nil, nil)
+				balcaoRepo.On("FindById", int64(1)).Return(nil, nil)
+			},
+			expectedError: "Balcão com ID 1 não foi encontrado",
+		},
+		{
+			name:       "Balcão cheio coloca o chamado na fila de espera",
+			chamadoDTO: &dto.CriarChamadoDTO{IDBalcao: 1},
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+				balcao := &entity.BalcaoEntity{Balcao: model.Balcao{ID: 1, Ativo: true}}
+				balcaoRepo.On("FindById", int64(1)).Return(balcao, nil)
+				chamadoRepo.On("FindBySerial", mock.Anything).Return(nil, nil) // Nenhum chamado com serial fornecido
+				balcaoRepo.On("ReservarVaga", int64(1), 5).Return(false, nil)  // Limite de atendimentos alcançado
+				chamadoRepo.On("Save", mock.MatchedBy(func(c *entity.ChamadoEntity) bool {
+					return c.StatusChamado == model.Aguardando
+				})).Return(&entity.ChamadoEntity{Chamado: model.Chamado{ID: 7, IDBalcao: 1, StatusChamado: model.Aguardando}}, nil)
+				chamadoRepo.On("FindByBalcaoAndStatus", mock.Anything, model.Aguardando).Return([]entity.ChamadoEntity{
+					{Chamado: model.Chamado{ID: 3}},
+					{Chamado: model.Chamado{ID: 7}},
+				}, nil)
+			},
+			expectedPosicao: 2,
+		},
+		{
+			name: "Chamado já existe para o serial",
+			chamadoDTO: &dto.CriarChamadoDTO{
+				SerialNumber: "123456",
+				CustomerID:   1,
+			},
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+				chamadoRepo.On("FindBySerial", "123456").Return(&entity.ChamadoEntity{Chamado: model.Chamado{CustomerID: 1, StatusChamado: "ABERTO"}}, nil)
+			},
+			expectedError: "Conflict: Já existe um chamado aberto para este serial.",
+		},
+		{
+			name: "Chamado criado com sucesso",
+			chamadoDTO: &dto.CriarChamadoDTO{
+				SerialNumber: "123456",
+				CustomerID:   1,
+				IDBalcao:     1,
+			},
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+				chamadoRepo.On("FindBySerial", "123456").Return(nil, nil) // Nenhum chamado com serial fornecido
+				balcao := &entity.BalcaoEntity{Balcao: model.Balcao{ID: 1, Ativo: true}}
+				balcaoRepo.On("FindById", int64(1)).Return(balcao, nil)
+				balcaoRepo.On("ReservarVaga", int64(1), 5).Return(true, nil) // Aceita o atendimento
+				chamadoRepo.On("Save", mock.Anything).Return(&entity.ChamadoEntity{}, nil)
+				atendimentoRepo.On("Save", mock.Anything).Return(nil)
+			},
+			expectedError: "",
+		},
+		{
+			name: "Falha ao salvar o chamado",
+			chamadoDTO: &dto.CriarChamadoDTO{
+				SerialNumber: "123456",
+				CustomerID:   1,
+				IDBalcao:     1,
+			},
+			mockSetup: func(chamadoRepo *MockChamadoRepository, balcaoRepo *MockBalcaoRepository, atendimentoRepo *MockAtendimentoRepository) {
+				chamadoRepo.On("FindBySerial", "123456").Return(nil, nil)
+				balcaoRepo.On("FindById", int64(1)).Return(&entity.BalcaoEntity{Balcao: model.Balcao{ID: 1, Capacidade: 2, Ativo: true}}, nil)
+				balcaoRepo.On("ReservarVaga", int64(1), 2).Return(true, nil)
+				chamadoRepo.On("Save", mock.Anything).Return(nil, errors.New("conexão perdida"))
+			},
+			expectedError: "Erro ao salvar o chamado: conexão perdida",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChamadoRepo := new(MockChamadoRepository)
+			mockBalcaoRepo := new(MockBalcaoRepository)
+			mockAtendimentoRepo := new(MockAtendimentoRepository)
+
+			mockClienteRepo := new(MockClienteRepository)
+			mockClienteRepo.On("FindById", mock.Anything).Return(&entity.ClienteEntity{Cliente: model.Cliente{Nome: "Ana"}}, nil).Maybe()
+			mockDispositivoRepo := new(MockDispositivoRepository)
+			mockDispositivoRepo.On("FindBySerial", mock.Anything).Return(nil, nil).Maybe()
+			mockDispositivoRepo.On("Save", mock.Anything).Return(entity.DispositivoEntity{}, nil).Maybe()
+
+			tt.mockSetup(mockChamadoRepo, mockBalcaoRepo, mockAtendimentoRepo)
+
+			cs := service.NovoChamadoService(mockChamadoRepo, mockBalcaoRepo, mockAtendimentoRepo, new(MockAtendenteRepository), mockClienteRepo, mockDispositivoRepo, new(MockProdutoRepository))
+
+			result, err := cs.CriarChamado(tt.chamadoDTO)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NotNil(t, result)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedPosicao, result.PosicaoFila)
+			}
+
+			mockChamadoRepo.AssertExpectations(t)
+			mockBalcaoRepo.AssertExpectations(t)
+			mockAtendimentoRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEditarChamadoTransicaoStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		atual         model.StatusChamado
+		novo          model.StatusChamado
+		expectedError string
+	}{
+		{
+			name:  "Resolvido para fechado",
+			atual: model.Resolvido,
+			novo:  model.Fechado,
+		},
+		{
+			name:          "Em andamento só por /assumir",
+			atual:         model.Aberto,
+			novo:          model.EmAndamento,
+			expectedError: "Transição de status inválida: ABERTO -> EM_ANDAMENTO. Use POST /chamados/{id}/assumir para iniciar o atendimento",
+		},
+		{
+			name:          "Aguardando não fura a fila",
+			atual:         model.Aguardando,
+			novo:          model.Aberto,
+			expectedError: "Transição de status inválida: AGUARDANDO -> ABERTO. O chamado sai da fila de espera quando o balcão libera uma vaga",
+		},
+		{
+			name:  "Status omitido mantém o atual",
+			atual: model.EmAndamento,
+			novo:  "",
+		},
+		{
+			name:          "Aberto direto para resolvido",
+			atual:         model.Aberto,
+			novo:          model.Resolvido,
+			expectedError: "Transição de status inválida: ABERTO -> RESOLVIDO",
+		},
+		{
+			name:          "Fechado não pode ser reaberto",
+			atual:         model.Fechado,
+			novo:          model.Aberto,
+			expectedError: "Transição de status inválida: FECHADO -> ABERTO",
+		},
+		{
+			name:          "Status desconhecido",
+			atual:         model.Aberto,
+			novo:          "CONCLUIDO",
+			expectedError: "Transição de status inválida: ABERTO -> CONCLUIDO",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockChamadoRepo := new(MockChamadoRepository)
+			mockBalcaoRepo := new(MockBalcaoRepository)
+			mockAtendimentoRepo := new(MockAtendimentoRepository)
+
+			existente := &entity.ChamadoEntity{Chamado: model.Chamado{ID: 10, IDBalcao: 1, StatusChamado: tt.atual}}
+			mockChamadoRepo.On("FindById", int64(10)).Return(existente, nil)
+			mockAtendimentoRepo.On("FindAbertoByChamado", int64(10)).Return(nil, nil).Maybe()
+
+			if tt.expectedError == "" {
+				mockChamadoRepo.On("Save", mock.Anything).Return(existente, nil)
+			}
+
+			cs := service.NovoChamadoService(mockChamadoRepo, mockBalcaoRepo, mockAtendimentoRepo, new(MockAtendenteRepository), new(MockClienteRepository), new(MockDispositivoRepository), new(MockProdutoRepository))
+			dtoEdicao := &dto.EditarChamadoDTO{StatusChamado: tt.novo}
+
+			result, err := cs.EditarChamado(10, dtoEdicao)
+
+			if tt.expectedError != "" {
+				assert.Nil(t, result)
+				var transicao *Exception.TransicaoInvalidaException
+				assert.ErrorAs(t, err, &transicao)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				expected := tt.novo
+				if expected == "" {
+					expected = tt.atual
+				}
+				assert.Equal(t, expected, result.StatusChamado)
+			}
+
+			mockChamadoRepo.AssertExpectations(t)
+			mockBalcaoRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResolverChamadoLiberaVagaDoBalcao(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	balcaoRepo := repository.NewBalcaoRepositoryMemoria(banco)
+	cs := service.NovoChamadoService(
+		repository.NewChamadoRepositoryMemoria(banco),
+		balcaoRepo,
+		repository.NewAtendimentoRepositoryMemoria(banco),
+		repository.NewAtendenteRepositoryMemoria(banco),
+		repository.NewClienteRepositoryMemoria(banco),
+		repository.NewDispositivoRepositoryMemoria(banco),
+		repository.NewProdutoRepositoryMemoria(banco),
+	)
+	joao, maria := cadastrarAtendentes(repository.NewAtendenteRepositoryMemoria(banco))
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 1)
+
+	balcao, _ := balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-1", IDBalcao: balcao.ID})
+	assert.NoError(t, err)
+
+	ocupado, _ := balcaoRepo.FindById(balcao.ID)
+	assert.Equal(t, 1, ocupado.FilaAtendimento)
+
+	_, err = cs.AssumirChamado(chamado.ID, joao)
+	assert.NoError(t, err)
+	resolvido, err := cs.ResolverChamado(chamado.ID, joao)
+	assert.NoError(t, err)
+	assert.False(t, resolvido.DataResolution.IsZero())
+
+	liberado, _ := balcaoRepo.FindById(balcao.ID)
+	assert.Equal(t, 0, liberado.FilaAtendimento)
+
+	reaberto, err := cs.ReabrirChamado(chamado.ID, maria)
+	assert.NoError(t, err)
+	assert.True(t, reaberto.DataResolution.IsZero())
+
+	ocupadoNovamente, _ := balcaoRepo.FindById(balcao.ID)
+	assert.Equal(t, 1, ocupadoNovamente.FilaAtendimento)
+}
+
+func TestFilaDeEsperaPromoveProximoAoLiberarVaga(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	balcaoRepo := repository.NewBalcaoRepositoryMemoria(banco)
+	cs := service.NovoChamadoService(
+		repository.NewChamadoRepositoryMemoria(banco),
+		balcaoRepo,
+		repository.NewAtendimentoRepositoryMemoria(banco),
+		repository.NewAtendenteRepositoryMemoria(banco),
+		repository.NewClienteRepositoryMemoria(banco),
+		repository.NewDispositivoRepositoryMemoria(banco),
+		repository.NewProdutoRepositoryMemoria(banco),
+	)
+	joao, _ := cadastrarAtendentes(repository.NewAtendenteRepositoryMemoria(banco))
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 1)
+	cs.LimiteAtendimentos = 1
+
+	balcao, _ := balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+
+	var criados []*entity.ChamadoEntity
+	for _, serial := range []string{"SN-1", "SN-2", "SN-3"} {
+		chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: serial, IDBalcao: balcao.ID})
+		assert.NoError(t, err)
+		criados = append(criados, chamado)
+	}
+
+	assert.Equal(t, model.Aberto, criados[0].StatusChamado)
+	assert.Equal(t, model.Aguardando, criados[1].StatusChamado)
+	assert.Equal(t, 1, criados[1].PosicaoFila)
+	assert.Equal(t, model.Aguardando, criados[2].StatusChamado)
+	assert.Equal(t, 2, criados[2].PosicaoFila)
+
+	_, err := cs.AssumirChamado(criados[0].ID, joao)
+	assert.NoError(t, err)
+	_, err = cs.ResolverChamado(criados[0].ID, joao)
+	assert.NoError(t, err)
+
+	promovido, err := cs.ChamadoDetalhado(criados[1].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Aberto, promovido.StatusChamado)
+	assert.Equal(t, 0, promovido.PosicaoFila)
+
+	ultimo, err := cs.ChamadoDetalhado(criados[2].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Aguardando, ultimo.StatusChamado)
+	assert.Equal(t, 1, ultimo.PosicaoFila)
+
+}
+
+func TestCriarChamadoConcorrenteNaoUltrapassaCapacidade(t *testing.T) {
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+	balcaoRepo := unidade.Repositorios().Balcoes
+	atendimentoRepo := unidade.Repositorios().Atendimentos
+	cs := service.NovoChamadoServiceTransacional(unidade)
+
+	balcao, _ := balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Capacidade: 3, Ativo: true}})
+
+	const total = 50
+	cadastrarClientes(unidade.Repositorios().Clientes, total)
+	var (
+		wg         sync.WaitGroup
+		mu         sync.Mutex
+		abertos    int
+		aguardando int
+	)
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{
+				CustomerID:   int64(i + 1),
+				SerialNumber: fmt.Sprintf("SN-%d", i),
+				IDBalcao:     balcao.ID,
+			})
+			if !assert.NoError(t, err) {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			switch chamado.StatusChamado {
+			case model.Aberto:
+				abertos++
+			case model.Aguardando:
+				aguardando++
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, abertos)
+	assert.Equal(t, total-3, aguardando)
+
+	ocupado, _ := balcaoRepo.FindById(balcao.ID)
+	assert.Equal(t, 3, ocupado.FilaAtendimento)
+
+	emAtendimento, err := atendimentoRepo.FindOpenByBalcao(balcao.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), emAtendimento)
+}
+
+func TestCicloDeVidaRegistraDatasDoAtendimento(t *testing.T) {
+	unidade := repository.NovaUnidadeDeTrabalhoMemoria(repository.NovoBancoMemoria())
+	repos := unidade.Repositorios()
+	cs := service.NovoChamadoServiceTransacional(unidade)
+	joao, maria := cadastrarAtendentes(repos.Atendentes)
+	cadastrarClientes(repos.Clientes, 1)
+
+	balcao, _ := repos.Balcoes.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-1", IDBalcao: balcao.ID})
+	assert.NoError(t, err)
+
+	atendimento, _ := repos.Atendimentos.FindAbertoByChamado(chamado.ID)
+	assert.False(t, atendimento.DataEntrada.IsZero())
+	assert.True(t, atendimento.DataInicio.IsZero())
+
+	_, err = cs.AssumirChamado(chamado.ID, joao)
+	assert.NoError(t, err)
+	atendimento, _ = repos.Atendimentos.FindAbertoByChamado(chamado.ID)
+	assert.False(t, atendimento.DataInicio.IsZero())
+	assert.Equal(t, model.EmAndamento, atendimento.Chamado.StatusChamado)
+
+	_, err = cs.ResolverChamado(chamado.ID, joao)
+	assert.NoError(t, err)
+	atendimento, _ = repos.Atendimentos.FindAbertoByChamado(chamado.ID)
+	assert.Nil(t, atendimento)
+
+	_, err = cs.ReabrirChamado(chamado.ID, maria)
+	assert.NoError(t, err)
+	atendimento, _ = repos.Atendimentos.FindAbertoByChamado(chamado.ID)
+	assert.NotNil(t, atendimento)
+	assert.True(t, atendimento.DataInicio.IsZero())
+}
+
+func TestBuscarChamadosPorCursor(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	chamadoRepo := repository.NewChamadoRepositoryMemoria(banco)
+	cs := service.NovoChamadoService(chamadoRepo, repository.NewBalcaoRepositoryMemoria(banco), repository.NewAtendimentoRepositoryMemoria(banco), repository.NewAtendenteRepositoryMemoria(banco), repository.NewClienteRepositoryMemoria(banco), repository.NewDispositivoRepositoryMemoria(banco), repository.NewProdutoRepositoryMemoria(banco))
+
+	for i := 0; i < 7; i++ {
+		chamadoRepo.Save(&entity.ChamadoEntity{Chamado: model.Chamado{CustomerID: int64(i%2 + 1), StatusChamado: model.Aberto}})
+	}
+
+	consulta := dto.ListarChamadosDTO{
+		Filtro:    model.FiltroChamados{CustomerID: 1},
+		Ordem:     model.OrdemChamados{Campo: model.OrdenarPorID, Decrescente: true},
+		PorCursor: true,
+		PageSize:  2,
+	}
+	ids := func(pagina *service.PaginaChamados) []int64 {
+		var ids []int64
+		for _, chamado := range pagina.Chamados {
+			ids = append(ids, chamado.ID)
+		}
+		return ids
+	}
+
+	primeira, err := cs.BuscarChamados(consulta)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), primeira.Total)
+	assert.Equal(t, []int64{7, 5}, ids(primeira))
+	assert.Empty(t, primeira.Anterior)
+
+	consulta.Cursor = primeira.Proximo
+	segunda, err := cs.BuscarChamados(consulta)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 1}, ids(segunda))
+	assert.Empty(t, segunda.Proximo)
+
+	consulta.Cursor = segunda.Anterior
+	voltou, err := cs.BuscarChamados(consulta)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{7, 5}, ids(voltou))
+	assert.Empty(t, voltou.Anterior)
+	assert.NotEmpty(t, voltou.Proximo)
+
+	consulta.Ordem.Decrescente = false
+	_, err = cs.BuscarChamados(consulta)
+	assert.EqualError(t, err, "Cursor inválido. (cursor: foi gerado para outra ordenação)")
+}
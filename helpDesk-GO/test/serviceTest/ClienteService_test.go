@@ -0,0 +1,105 @@
+package serviceTest
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"testing"
+)
+
+type MockClienteRepository struct {
+	mock.Mock
+}
+
+func (m *MockClienteRepository) FindAll() ([]entity.ClienteEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.ClienteEntity), args.Error(1)
+}
+
+func (m *MockClienteRepository) FindById(id int64) (*entity.ClienteEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ClienteEntity), args.Error(1)
+}
+
+func (m *MockClienteRepository) FindByDocumento(documento string) (*entity.ClienteEntity, error) {
+	args := m.Called(documento)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ClienteEntity), args.Error(1)
+}
+
+func (m *MockClienteRepository) Save(cliente entity.ClienteEntity) (entity.ClienteEntity, error) {
+	args := m.Called(cliente)
+	return args.Get(0).(entity.ClienteEntity), args.Error(1)
+}
+
+// cadastrarClientes grava n clientes, com IDs de 1 a n.
+func cadastrarClientes(repo repository.ClienteRepository, n int) {
+	for i := 1; i <= n; i++ {
+		repo.Save(entity.ClienteEntity{Cliente: model.Cliente{Nome: fmt.Sprintf("Cliente %d", i)}})
+	}
+}
+
+func TestCadastrarClienteNormalizaDocumento(t *testing.T) {
+	cs := service.NovoClienteService(repository.NewClienteRepositoryMemoria(repository.NovoBancoMemoria()))
+
+	cliente, err := cs.CadastrarCliente(&dto.ClienteDTO{Nome: "Ana", Documento: "529.982.247-25"})
+	assert.NoError(t, err)
+	assert.Equal(t, "52998224725", cliente.Documento)
+
+	_, err = cs.CadastrarCliente(&dto.ClienteDTO{Nome: "Outra Ana", Documento: "52998224725"})
+	var conflito *Exception.ConflictException
+	if assert.ErrorAs(t, err, &conflito) {
+		assert.Equal(t, "/api/v1/clientes/1", conflito.Uri)
+	}
+
+	editado, err := cs.EditarCliente(cliente.ID, &dto.ClienteDTO{Nome: "Ana Souza", Documento: "529 982 247 25"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ana Souza", editado.Nome)
+}
+
+func TestCriarChamadoClienteInexistente(t *testing.T) {
+	mockClienteRepo := new(MockClienteRepository)
+	mockClienteRepo.On("FindById", int64(9)).Return(nil, nil)
+	cs := service.NovoChamadoService(new(MockChamadoRepository), new(MockBalcaoRepository), new(MockAtendimentoRepository), new(MockAtendenteRepository), mockClienteRepo, new(MockDispositivoRepository), new(MockProdutoRepository))
+
+	result, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 9, SerialNumber: "SN-1", IDBalcao: 1})
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "Cliente com ID 9 não foi encontrado")
+	mockClienteRepo.AssertExpectations(t)
+}
+
+func TestListaChamadosCustomerIdSemChamados(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	clienteRepo := repository.NewClienteRepositoryMemoria(banco)
+	cs := service.NovoChamadoService(
+		repository.NewChamadoRepositoryMemoria(banco),
+		repository.NewBalcaoRepositoryMemoria(banco),
+		repository.NewAtendimentoRepositoryMemoria(banco),
+		repository.NewAtendenteRepositoryMemoria(banco),
+		clienteRepo,
+		repository.NewDispositivoRepositoryMemoria(banco),
+		repository.NewProdutoRepositoryMemoria(banco),
+	)
+	cadastrarClientes(clienteRepo, 1)
+
+	chamados, err := cs.ListaChamadosCustomerId(1)
+	assert.NoError(t, err)
+	assert.NotNil(t, chamados)
+	assert.Empty(t, chamados)
+
+	_, err = cs.ListaChamadosCustomerId(2)
+	var naoEncontrado *Exception.NotFoundException
+	assert.ErrorAs(t, err, &naoEncontrado)
+}
@@ -0,0 +1,97 @@
+package serviceTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"testing"
+	"time"
+)
+
+type MockDispositivoRepository struct {
+	mock.Mock
+}
+
+func (m *MockDispositivoRepository) FindBySerial(serial string) (*entity.DispositivoEntity, error) {
+	args := m.Called(serial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DispositivoEntity), args.Error(1)
+}
+
+func (m *MockDispositivoRepository) Save(dispositivo entity.DispositivoEntity) (entity.DispositivoEntity, error) {
+	args := m.Called(dispositivo)
+	return args.Get(0).(entity.DispositivoEntity), args.Error(1)
+}
+
+func novoDispositivoServiceMemoria(banco *repository.BancoMemoria) *service.DispositivoService {
+	return service.NovoDispositivoService(
+		repository.NewDispositivoRepositoryMemoria(banco),
+		repository.NewClienteRepositoryMemoria(banco),
+		repository.NewChamadoRepositoryMemoria(banco),
+	)
+}
+
+func TestCadastrarDispositivo(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 1)
+	ds := novoDispositivoServiceMemoria(banco)
+
+	dispositivo, err := ds.CadastrarDispositivo(&dto.CriarDispositivoDTO{SerialNumber: "SN-1", Produto: "Notebook", CustomerID: 1, DataCompra: "2024-03-10"})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC), dispositivo.DataCompra)
+
+	_, err = ds.CadastrarDispositivo(&dto.CriarDispositivoDTO{SerialNumber: "SN-1", CustomerID: 1})
+	var conflito *Exception.ConflictException
+	if assert.ErrorAs(t, err, &conflito) {
+		assert.Equal(t, Exception.CodigoDispositivoDuplicado, conflito.Codigo)
+	}
+
+	_, err = ds.CadastrarDispositivo(&dto.CriarDispositivoDTO{SerialNumber: "SN-2", CustomerID: 2})
+	assert.EqualError(t, err, "Cliente com ID 2 não foi encontrado")
+
+	amanha := time.Now().AddDate(0, 0, 1).Format(dto.FormatoDataCompra)
+	_, err = ds.CadastrarDispositivo(&dto.CriarDispositivoDTO{SerialNumber: "SN-2", CustomerID: 1, DataCompra: amanha})
+	var validacao *Exception.ValidationException
+	if assert.ErrorAs(t, err, &validacao) {
+		assert.Equal(t, map[string]string{"data_compra": "não pode estar no futuro"}, validacao.Campos)
+	}
+}
+
+func TestHistoricoDispositivoDesconhecido(t *testing.T) {
+	ds := novoDispositivoServiceMemoria(repository.NovoBancoMemoria())
+
+	_, err := ds.HistoricoDispositivo("SN-9")
+	assert.EqualError(t, err, "Dispositivo SN-9 não foi encontrado")
+}
+
+func TestCriarChamadoCadastraDispositivo(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	dispositivoRepo := repository.NewDispositivoRepositoryMemoria(banco)
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 2)
+	repository.NewBalcaoRepositoryMemoria(banco).Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	cs := service.NovoChamadoServiceTransacional(repository.NovaUnidadeDeTrabalhoMemoria(banco))
+
+	_, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-1", Produto: "Impressora", IDBalcao: 1})
+	assert.NoError(t, err)
+
+	dispositivo, _ := dispositivoRepo.FindBySerial("SN-1")
+	if assert.NotNil(t, dispositivo) {
+		assert.Equal(t, "Impressora", dispositivo.Produto)
+		assert.Equal(t, int64(1), dispositivo.CustomerID)
+	}
+
+	dispositivoRepo.Save(entity.DispositivoEntity{Dispositivo: model.Dispositivo{SerialNumber: "SN-2", Produto: "Notebook", CustomerID: 1}})
+	chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 2, SerialNumber: "SN-2", IDBalcao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Notebook", chamado.Produto)
+	}
+	dispositivo, _ = dispositivoRepo.FindBySerial("SN-2")
+	assert.Equal(t, int64(1), dispositivo.CustomerID)
+}
@@ -0,0 +1,103 @@
+package serviceTest
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type garantiaFixa struct {
+	status model.StatusGarantia
+	err    error
+}
+
+func (g garantiaFixa) ConsultarGarantia(serial string) (model.StatusGarantia, error) {
+	return g.status, g.err
+}
+
+func TestGarantiaPorDataCompra(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	dispositivoRepo := repository.NewDispositivoRepositoryMemoria(banco)
+	hoje := time.Now()
+	dispositivoRepo.Save(entity.DispositivoEntity{Dispositivo: model.Dispositivo{SerialNumber: "SN-NOVO", DataCompra: hoje.AddDate(0, -11, 0)}})
+	dispositivoRepo.Save(entity.DispositivoEntity{Dispositivo: model.Dispositivo{SerialNumber: "SN-VELHO", DataCompra: hoje.AddDate(0, -13, 0)}})
+	dispositivoRepo.Save(entity.DispositivoEntity{Dispositivo: model.Dispositivo{SerialNumber: "SN-SEM-DATA"}})
+
+	garantia := &service.GarantiaPorDataCompra{Dispositivos: dispositivoRepo, Meses: 12}
+	for serial, esperado := range map[string]model.StatusGarantia{
+		"SN-NOVO":     model.EmGarantia,
+		"SN-VELHO":    model.ForaDaGarantia,
+		"SN-SEM-DATA": model.GarantiaDesconhecida,
+		"SN-9":        model.GarantiaDesconhecida,
+	} {
+		status, err := garantia.ConsultarGarantia(serial)
+		assert.NoError(t, err)
+		assert.Equal(t, esperado, status, serial)
+	}
+}
+
+func TestGarantiaArquivo(t *testing.T) {
+	hoje := time.Now().Format(dto.FormatoDataCompra)
+	caminho := filepath.Join(t.TempDir(), "garantias.csv")
+	conteudo := "# serial,último dia da garantia\nSN-1,2099-12-31\nSN-2,2020-01-31\nSN-3," + hoje + "\n"
+	assert.NoError(t, os.WriteFile(caminho, []byte(conteudo), 0o600))
+
+	garantia, err := service.CarregarGarantiaArquivo(caminho, garantiaFixa{status: model.ForaDaGarantia})
+	if !assert.NoError(t, err) {
+		return
+	}
+	for serial, esperado := range map[string]model.StatusGarantia{
+		"SN-1": model.EmGarantia,
+		"SN-2": model.ForaDaGarantia,
+		"SN-3": model.EmGarantia,
+		"SN-9": model.ForaDaGarantia,
+	} {
+		status, err := garantia.ConsultarGarantia(serial)
+		assert.NoError(t, err)
+		assert.Equal(t, esperado, status, serial)
+	}
+
+	assert.NoError(t, os.WriteFile(caminho, []byte("SN-1,31/12/2099\n"), 0o600))
+	_, err = service.CarregarGarantiaArquivo(caminho, nil)
+	assert.ErrorContains(t, err, "linha 1")
+}
+
+func TestCriarChamadoForaDaGarantiaVaiParaReparoPago(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 1)
+	balcaoRepo := repository.NewBalcaoRepositoryMemoria(banco)
+	balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "Reparo pago", Ativo: true}})
+
+	cs := service.NovoChamadoServiceTransacional(repository.NovaUnidadeDeTrabalhoMemoria(banco))
+	cs.RegrasGarantia = []service.RegraGarantia{service.EncaminharForaDaGarantia(2)}
+
+	cs.Garantia = garantiaFixa{status: model.ForaDaGarantia}
+	chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-1", IDBalcao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.ForaDaGarantia, chamado.Garantia)
+		assert.Equal(t, int64(2), chamado.IDBalcao)
+	}
+
+	cs.Garantia = garantiaFixa{status: model.EmGarantia}
+	chamado, err = cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-2", IDBalcao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.EmGarantia, chamado.Garantia)
+		assert.Equal(t, int64(1), chamado.IDBalcao)
+	}
+
+	cs.Garantia = garantiaFixa{err: errors.New("fabricante fora do ar")}
+	chamado, err = cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-3", IDBalcao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, model.GarantiaDesconhecida, chamado.Garantia)
+		assert.Equal(t, int64(1), chamado.IDBalcao)
+	}
+}
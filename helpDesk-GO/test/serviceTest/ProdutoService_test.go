@@ -0,0 +1,96 @@
+package serviceTest
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"helpdesk/Exception"
+	"helpdesk/dto"
+	"helpdesk/entity"
+	"helpdesk/model"
+	"helpdesk/repository"
+	"helpdesk/service"
+	"testing"
+)
+
+type MockProdutoRepository struct {
+	mock.Mock
+}
+
+func (m *MockProdutoRepository) FindAll() ([]entity.ProdutoEntity, error) {
+	args := m.Called()
+	return args.Get(0).([]entity.ProdutoEntity), args.Error(1)
+}
+
+func (m *MockProdutoRepository) FindById(id int64) (*entity.ProdutoEntity, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProdutoEntity), args.Error(1)
+}
+
+func (m *MockProdutoRepository) FindBySku(sku string) (*entity.ProdutoEntity, error) {
+	args := m.Called(sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ProdutoEntity), args.Error(1)
+}
+
+func (m *MockProdutoRepository) Save(produto entity.ProdutoEntity) (entity.ProdutoEntity, error) {
+	args := m.Called(produto)
+	return args.Get(0).(entity.ProdutoEntity), args.Error(1)
+}
+
+func TestCadastrarProduto(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	repository.NewBalcaoRepositoryMemoria(banco).Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	ps := service.NovoProdutoService(repository.NewProdutoRepositoryMemoria(banco), repository.NewBalcaoRepositoryMemoria(banco))
+
+	produto, err := ps.CadastrarProduto(&dto.ProdutoDTO{SKU: "nb-100", Nome: "Notebook 100", Categoria: "Notebooks", IDBalcaoPadrao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "NB-100", produto.SKU)
+	}
+
+	_, err = ps.CadastrarProduto(&dto.ProdutoDTO{SKU: "NB-100", Nome: "Outro", Categoria: "Notebooks"})
+	var conflito *Exception.ConflictException
+	if assert.ErrorAs(t, err, &conflito) {
+		assert.Equal(t, Exception.CodigoProdutoDuplicado, conflito.Codigo)
+		assert.Equal(t, "/api/v1/produtos/1", conflito.Uri)
+	}
+
+	_, err = ps.CadastrarProduto(&dto.ProdutoDTO{SKU: "IMP-1", Nome: "Impressora", Categoria: "Impressoras", IDBalcaoPadrao: 9})
+	assert.EqualError(t, err, "Balcão com ID 9 não foi encontrado")
+}
+
+func TestCriarChamadoUsaBalcaoPadraoDoProduto(t *testing.T) {
+	banco := repository.NovoBancoMemoria()
+	cadastrarClientes(repository.NewClienteRepositoryMemoria(banco), 1)
+	balcaoRepo := repository.NewBalcaoRepositoryMemoria(banco)
+	balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "João", Ativo: true}})
+	balcaoRepo.Save(entity.BalcaoEntity{Balcao: model.Balcao{NomeAtendente: "Maria", Ativo: true}})
+	produtoRepo := repository.NewProdutoRepositoryMemoria(banco)
+	produtoRepo.Save(entity.ProdutoEntity{Produto: model.Produto{SKU: "NB-100", Nome: "Notebook 100", Categoria: "Notebooks", IDBalcaoPadrao: 2}})
+	produtoRepo.Save(entity.ProdutoEntity{Produto: model.Produto{SKU: "IMP-1", Nome: "Impressora", Categoria: "Impressoras"}})
+	cs := service.NovoChamadoServiceTransacional(repository.NovaUnidadeDeTrabalhoMemoria(banco))
+
+	chamado, err := cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-1", IDProduto: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), chamado.IDBalcao)
+		assert.Equal(t, "Notebook 100", chamado.Produto)
+	}
+
+	chamado, err = cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-2", IDProduto: 1, IDBalcao: 1})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), chamado.IDBalcao)
+	}
+
+	_, err = cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-3", IDProduto: 2})
+	var validacao *Exception.ValidationException
+	if assert.ErrorAs(t, err, &validacao) {
+		assert.Contains(t, validacao.Campos, "id_balcao")
+	}
+
+	_, err = cs.CriarChamado(&dto.CriarChamadoDTO{CustomerID: 1, SerialNumber: "SN-3", IDProduto: 9, IDBalcao: 1})
+	assert.EqualError(t, err, "Produto com ID 9 não foi encontrado")
+}
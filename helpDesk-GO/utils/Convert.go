@@ -8,7 +8,11 @@ import (
 func ConvertBalcaoEntityToBalcao(balcaoEntity *entity.BalcaoEntity) *model.Balcao {
 	return &model.Balcao{
 		ID:              balcaoEntity.ID,
+		IDAtendente:     balcaoEntity.IDAtendente,
 		NomeAtendente:   balcaoEntity.NomeAtendente,
 		FilaAtendimento: balcaoEntity.FilaAtendimento,
+		Capacidade:      balcaoEntity.Capacidade,
+		Ativo:           balcaoEntity.Ativo,
+		Versao:          balcaoEntity.Versao,
 	}
 }